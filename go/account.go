@@ -0,0 +1,89 @@
+package screencraft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	accountEndpoint = "/account"
+)
+
+// Account represents the authenticated account and what its plan includes.
+type Account struct {
+	RetryInfo `json:"-"`
+
+	// ID is the account ID.
+	ID string `json:"id"`
+	// Plan is the name of the account's plan.
+	Plan string `json:"plan"`
+	// Features lists the capabilities included in the plan.
+	Features Entitlements `json:"features"`
+	// Limits are the limits of the plan.
+	Limits PlanLimits `json:"limits"`
+}
+
+// Entitlements describes which features a plan includes.
+type Entitlements struct {
+	// Video reports whether video capture is available.
+	Video bool `json:"video"`
+	// OCR reports whether text extraction is available.
+	OCR bool `json:"ocr"`
+	// Batch reports whether batch endpoints are available.
+	Batch bool `json:"batch"`
+	// MaxBatchSize is the maximum number of items per batch, or 0 if unlimited.
+	MaxBatchSize int `json:"maxBatchSize"`
+	// Webhooks reports whether async operations with webhooks are available.
+	Webhooks bool `json:"webhooks"`
+	// Proxies reports whether ScreenCraft proxies are available.
+	Proxies bool `json:"proxies"`
+}
+
+// accountResponse is the API response for an account request.
+type accountResponse struct {
+	APIResponse
+	Data *Account `json:"data,omitempty"`
+}
+
+// Account returns the plan, feature entitlements and limits of the account
+// the client authenticates as.
+//
+// Example:
+//
+//	account, err := client.Account(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !account.Features.Video {
+//	    disableVideoCapture()
+//	}
+func (c *Client) Account(ctx context.Context) (*Account, error) {
+	resp, info, err := c.doRequest(ctx, http.MethodGet, accountEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var accountResp accountResponse
+	if err := c.decodeJSON(body, &accountResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !accountResp.Success || accountResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    accountResp.Message,
+		}
+	}
+
+	account := accountResp.Data
+	account.RetryInfo = info
+	return account, nil
+}
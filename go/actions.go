@@ -0,0 +1,241 @@
+package screencraft
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Action is a browser interaction performed after page load and before
+// capture. Actions run in order.
+//
+// Use the builder functions to create actions:
+//
+//	Actions: []screencraft.Action{
+//	    screencraft.Click("#menu-toggle"),
+//	    screencraft.WaitFor(".menu.open"),
+//	    screencraft.Hover(".menu .pricing"),
+//	},
+type Action interface {
+	// ActionType returns the action type sent to the API.
+	ActionType() string
+
+	validate() error
+}
+
+// ClickAction clicks an element.
+type ClickAction struct {
+	// Selector is the CSS selector of the element to click.
+	Selector string `json:"selector"`
+	// Button is the mouse button ("left", "right", "middle"). Defaults to left.
+	Button string `json:"button,omitempty"`
+	// ClickCount is the number of clicks (2 for a double click).
+	ClickCount int `json:"clickCount,omitempty"`
+}
+
+// TypeAction types text into an element.
+type TypeAction struct {
+	// Selector is the CSS selector of the input element.
+	Selector string `json:"selector"`
+	// Text is the text to type.
+	Text string `json:"text"`
+	// Delay is the time between key presses in milliseconds.
+	Delay int `json:"delay,omitempty"`
+}
+
+// HoverAction moves the mouse over an element.
+type HoverAction struct {
+	// Selector is the CSS selector of the element to hover.
+	Selector string `json:"selector"`
+}
+
+// ScrollAction scrolls the page, either to an element or by an offset.
+type ScrollAction struct {
+	// Selector is the CSS selector of an element to scroll into view.
+	Selector string `json:"selector,omitempty"`
+	// X is the horizontal scroll offset in pixels.
+	X int `json:"x,omitempty"`
+	// Y is the vertical scroll offset in pixels.
+	Y int `json:"y,omitempty"`
+}
+
+// WaitForAction waits for an element to appear.
+type WaitForAction struct {
+	// Selector is the CSS selector to wait for.
+	Selector string `json:"selector"`
+	// Timeout is the maximum wait time in milliseconds.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// PressAction presses a keyboard key.
+type PressAction struct {
+	// Key is the key to press (e.g., "Enter", "Escape", "ArrowDown").
+	Key string `json:"key"`
+}
+
+// Click returns an action that clicks the element matching selector.
+func Click(selector string) ClickAction {
+	return ClickAction{Selector: selector}
+}
+
+// TypeText returns an action that types text into the element matching selector.
+func TypeText(selector, text string) TypeAction {
+	return TypeAction{Selector: selector, Text: text}
+}
+
+// Hover returns an action that hovers the element matching selector.
+func Hover(selector string) HoverAction {
+	return HoverAction{Selector: selector}
+}
+
+// ScrollTo returns an action that scrolls the element matching selector into view.
+func ScrollTo(selector string) ScrollAction {
+	return ScrollAction{Selector: selector}
+}
+
+// ScrollBy returns an action that scrolls the page by the given offset.
+func ScrollBy(x, y int) ScrollAction {
+	return ScrollAction{X: x, Y: y}
+}
+
+// WaitFor returns an action that waits for the element matching selector.
+func WaitFor(selector string) WaitForAction {
+	return WaitForAction{Selector: selector}
+}
+
+// Press returns an action that presses the given key.
+func Press(key string) PressAction {
+	return PressAction{Key: key}
+}
+
+// ActionType implements Action.
+func (a ClickAction) ActionType() string { return "click" }
+
+// ActionType implements Action.
+func (a TypeAction) ActionType() string { return "type" }
+
+// ActionType implements Action.
+func (a HoverAction) ActionType() string { return "hover" }
+
+// ActionType implements Action.
+func (a ScrollAction) ActionType() string { return "scroll" }
+
+// ActionType implements Action.
+func (a WaitForAction) ActionType() string { return "waitFor" }
+
+// ActionType implements Action.
+func (a PressAction) ActionType() string { return "press" }
+
+// MarshalJSON implements json.Marshaler.
+func (a ClickAction) MarshalJSON() ([]byte, error) {
+	type alias ClickAction
+	return marshalAction(a, alias(a))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a TypeAction) MarshalJSON() ([]byte, error) {
+	type alias TypeAction
+	return marshalAction(a, alias(a))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a HoverAction) MarshalJSON() ([]byte, error) {
+	type alias HoverAction
+	return marshalAction(a, alias(a))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a ScrollAction) MarshalJSON() ([]byte, error) {
+	type alias ScrollAction
+	return marshalAction(a, alias(a))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a WaitForAction) MarshalJSON() ([]byte, error) {
+	type alias WaitForAction
+	return marshalAction(a, alias(a))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a PressAction) MarshalJSON() ([]byte, error) {
+	type alias PressAction
+	return marshalAction(a, alias(a))
+}
+
+// marshalAction encodes fields as a JSON object with an added "type" member.
+func marshalAction(a Action, fields interface{}) ([]byte, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	obj["type"] = a.ActionType()
+
+	return json.Marshal(obj)
+}
+
+func (a ClickAction) validate() error {
+	if a.Selector == "" {
+		return fmt.Errorf("selector is required")
+	}
+	switch a.Button {
+	case "", "left", "right", "middle":
+	default:
+		return fmt.Errorf("unknown mouse button %q", a.Button)
+	}
+	return nil
+}
+
+func (a TypeAction) validate() error {
+	if a.Selector == "" {
+		return fmt.Errorf("selector is required")
+	}
+	return nil
+}
+
+func (a HoverAction) validate() error {
+	if a.Selector == "" {
+		return fmt.Errorf("selector is required")
+	}
+	return nil
+}
+
+func (a ScrollAction) validate() error {
+	if a.Selector == "" && a.X == 0 && a.Y == 0 {
+		return fmt.Errorf("selector or offset is required")
+	}
+	return nil
+}
+
+func (a WaitForAction) validate() error {
+	if a.Selector == "" {
+		return fmt.Errorf("selector is required")
+	}
+	if a.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	return nil
+}
+
+func (a PressAction) validate() error {
+	if a.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+	return nil
+}
+
+// validateActions validates a list of actions.
+func validateActions(actions []Action) error {
+	for i, a := range actions {
+		if a == nil {
+			return NewValidationError(fmt.Sprintf("actions[%d]", i), "action must not be nil", "required").Error
+		}
+		if err := a.validate(); err != nil {
+			return NewValidationError(fmt.Sprintf("actions[%d]", i), fmt.Sprintf("%s action: %v", a.ActionType(), err), "action").Error
+		}
+	}
+	return nil
+}
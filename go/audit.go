@@ -0,0 +1,156 @@
+package screencraft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	auditEndpoint = "/audits"
+)
+
+// AuditCategory represents a performance audit category.
+type AuditCategory string
+
+const (
+	// AuditPerformance audits page load performance.
+	AuditPerformance AuditCategory = "performance"
+	// AuditAccessibility audits accessibility.
+	AuditAccessibility AuditCategory = "accessibility"
+	// AuditBestPractices audits web best practices.
+	AuditBestPractices AuditCategory = "best-practices"
+	// AuditSEO audits search engine optimization.
+	AuditSEO AuditCategory = "seo"
+)
+
+// AuditOptions represents options for running a performance audit.
+type AuditOptions struct {
+	// URL is the target URL to audit.
+	URL string `json:"url"`
+	// Categories limits the audit to the given categories (all if empty).
+	Categories []AuditCategory `json:"categories,omitempty"`
+}
+
+// AuditScores contains the category scores of an audit (0-100).
+type AuditScores struct {
+	// Performance is the performance score.
+	Performance float64 `json:"performance"`
+	// Accessibility is the accessibility score.
+	Accessibility float64 `json:"accessibility"`
+	// BestPractices is the best practices score.
+	BestPractices float64 `json:"bestPractices"`
+	// SEO is the search engine optimization score.
+	SEO float64 `json:"seo"`
+}
+
+// AuditMetrics contains the core web vitals measured during an audit.
+type AuditMetrics struct {
+	// LCP is the Largest Contentful Paint in milliseconds.
+	LCP float64 `json:"lcp"`
+	// CLS is the Cumulative Layout Shift score.
+	CLS float64 `json:"cls"`
+	// TBT is the Total Blocking Time in milliseconds.
+	TBT float64 `json:"tbt"`
+	// FCP is the First Contentful Paint in milliseconds.
+	FCP float64 `json:"fcp"`
+	// SpeedIndex is the Speed Index in milliseconds.
+	SpeedIndex float64 `json:"speedIndex"`
+}
+
+// AuditResult represents the result of a performance audit.
+type AuditResult struct {
+	RetryInfo
+
+	// URL is the audited URL.
+	URL string
+	// Scores contains the category scores.
+	Scores AuditScores
+	// Metrics contains the measured performance metrics.
+	Metrics AuditMetrics
+	// Raw is the full audit report as returned by the API.
+	Raw json.RawMessage
+}
+
+// auditResponse is the API response for an audit request.
+type auditResponse struct {
+	APIResponse
+	Data *struct {
+		Scores  AuditScores     `json:"scores"`
+		Metrics AuditMetrics    `json:"metrics"`
+		Report  json.RawMessage `json:"report,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// Audit runs a Lighthouse performance audit of the specified URL.
+//
+// Example:
+//
+//	result, err := client.Audit(ctx, &screencraft.AuditOptions{
+//	    URL:        "https://example.com",
+//	    Categories: []screencraft.AuditCategory{screencraft.AuditPerformance},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Performance: %.0f, LCP: %.0fms\n", result.Scores.Performance, result.Metrics.LCP)
+func (c *Client) Audit(ctx context.Context, opts *AuditOptions) (*AuditResult, error) {
+	if err := ValidateAuditOptions(opts); err != nil {
+		return nil, err
+	}
+
+	resp, info, err := c.doRequest(ctx, http.MethodPost, auditEndpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var auditResp auditResponse
+	if err := c.decodeJSON(body, &auditResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !auditResp.Success || auditResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    auditResp.Message,
+		}
+	}
+
+	result := &AuditResult{
+		RetryInfo: info,
+		URL:       opts.URL,
+		Scores:    auditResp.Data.Scores,
+		Metrics:   auditResp.Data.Metrics,
+		Raw:       auditResp.Data.Report,
+	}
+	if len(result.Raw) == 0 {
+		result.Raw = json.RawMessage(body)
+	}
+
+	return result, nil
+}
+
+// ValidateAuditOptions validates audit options.
+func ValidateAuditOptions(opts *AuditOptions) error {
+	if opts == nil || opts.URL == "" {
+		return ErrMissingURL
+	}
+
+	for _, category := range opts.Categories {
+		switch category {
+		case AuditPerformance, AuditAccessibility, AuditBestPractices, AuditSEO:
+		default:
+			return NewValidationError("categories", fmt.Sprintf("unknown audit category %q", category), "enum").Error
+		}
+	}
+
+	return nil
+}
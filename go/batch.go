@@ -0,0 +1,196 @@
+package screencraft
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default number of batch captures the client
+// runs concurrently, across all batches.
+const DefaultBatchConcurrency = 4
+
+// ScreenshotBatchResult is the outcome of one capture in a screenshot batch.
+type ScreenshotBatchResult struct {
+	// Index is the position of the options in the submitted batch.
+	Index int
+	// Options are the submitted capture options.
+	Options *ScreenshotOptions
+	// Result is the screenshot, if the capture succeeded.
+	Result *ScreenshotResult
+	// Err is the capture error, if any.
+	Err error
+}
+
+// PDFBatchResult is the outcome of one capture in a PDF batch.
+type PDFBatchResult struct {
+	// Index is the position of the options in the submitted batch.
+	Index int
+	// Options are the submitted PDF options.
+	Options *PDFOptions
+	// Result is the PDF, if generation succeeded.
+	Result *PDFResult
+	// Err is the generation error, if any.
+	Err error
+}
+
+// WithBatchConcurrency sets how many batch captures the client runs
+// concurrently, shared by all batches. Queued captures are dispatched in
+// priority order.
+func WithBatchConcurrency(n int) Option {
+	return func(c *Client) {
+		c.batchScheduler.setCapacity(n)
+	}
+}
+
+// ScreenshotBatch captures multiple screenshots concurrently.
+//
+// Captures of all batches on the client share the batch concurrency limit
+// (see WithBatchConcurrency) and are dispatched by priority, so a batch
+// submitted with PriorityHigh overtakes queued lower-priority batches.
+// Results are returned in submission order.
+//
+// Example:
+//
+//	results := client.ScreenshotBatch(ctx, []*screencraft.ScreenshotOptions{
+//	    {URL: "https://example.com"},
+//	    {URL: "https://example.org"},
+//	}, screencraft.WithPriority(screencraft.PriorityLow))
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("%s: %v", r.Options.URL, r.Err)
+//	    }
+//	}
+func (c *Client) ScreenshotBatch(ctx context.Context, items []*ScreenshotOptions, callOpts ...CallOption) []ScreenshotBatchResult {
+	results := make([]ScreenshotBatchResult, len(items))
+	for r := range c.ScreenshotBatchStream(ctx, items, callOpts...) {
+		results[r.Index] = r
+	}
+	return results
+}
+
+// ScreenshotBatchStream captures multiple screenshots concurrently and
+// delivers each result as soon as its capture completes.
+//
+// Results arrive in completion order; use Index to match them to the
+// submitted options. The channel is closed after the last result. It is
+// buffered for the whole batch, so abandoning it early does not leak
+// goroutines.
+//
+// Example:
+//
+//	for r := range client.ScreenshotBatchStream(ctx, items) {
+//	    if r.Err != nil {
+//	        log.Printf("%s: %v", r.Options.URL, r.Err)
+//	        continue
+//	    }
+//	    upload(r.Result)
+//	}
+func (c *Client) ScreenshotBatchStream(ctx context.Context, items []*ScreenshotOptions, callOpts ...CallOption) <-chan ScreenshotBatchResult {
+	ctx = withCallOptions(ctx, callOpts)
+	results := make(chan ScreenshotBatchResult, len(items))
+
+	go func() {
+		defer close(results)
+		c.runBatch(ctx, len(items), func(i int) string {
+			if items[i] == nil {
+				return ""
+			}
+			return items[i].URL
+		}, func(ctx context.Context, i int) {
+			result, err := c.Screenshot(ctx, items[i])
+			results <- ScreenshotBatchResult{Index: i, Options: items[i], Result: result, Err: err}
+		}, func(i int, err error) {
+			results <- ScreenshotBatchResult{Index: i, Options: items[i], Err: err}
+		})
+	}()
+
+	return results
+}
+
+// PDFBatch generates multiple PDFs concurrently.
+//
+// It shares the batch concurrency limit and priority scheduling with
+// ScreenshotBatch. Results are returned in submission order.
+func (c *Client) PDFBatch(ctx context.Context, items []*PDFOptions, callOpts ...CallOption) []PDFBatchResult {
+	results := make([]PDFBatchResult, len(items))
+	for r := range c.PDFBatchStream(ctx, items, callOpts...) {
+		results[r.Index] = r
+	}
+	return results
+}
+
+// PDFBatchStream generates multiple PDFs concurrently and delivers each
+// result as soon as it completes, in completion order. See
+// ScreenshotBatchStream.
+func (c *Client) PDFBatchStream(ctx context.Context, items []*PDFOptions, callOpts ...CallOption) <-chan PDFBatchResult {
+	ctx = withCallOptions(ctx, callOpts)
+	results := make(chan PDFBatchResult, len(items))
+
+	go func() {
+		defer close(results)
+		c.runBatch(ctx, len(items), func(i int) string {
+			if items[i] == nil {
+				return ""
+			}
+			return pdfTargetURL(items[i])
+		}, func(ctx context.Context, i int) {
+			result, err := c.PDF(ctx, items[i])
+			results <- PDFBatchResult{Index: i, Options: items[i], Result: result, Err: err}
+		}, func(i int, err error) {
+			results <- PDFBatchResult{Index: i, Options: items[i], Err: err}
+		})
+	}()
+
+	return results
+}
+
+// runBatch runs n tasks, each after acquiring a batch slot at the priority
+// carried by ctx. If a politeness policy is set, each task with a target URL
+// first waits for it to be allowed; tasks without one are left to fail
+// validation in run. fail is called for tasks that could not be started.
+func (c *Client) runBatch(ctx context.Context, n int, target func(i int) string, run func(ctx context.Context, i int), fail func(i int, err error)) {
+	priority := callOptionsFrom(ctx).priority
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Wait for the host before taking a slot, so paced hosts do not
+			// hold up captures of other hosts
+			if targetURL := target(i); c.politeness != nil && targetURL != "" {
+				if err := c.politeness.Wait(ctx, targetURL); err != nil {
+					fail(i, err)
+					return
+				}
+			}
+
+			release, err := c.batchScheduler.acquire(ctx, priority)
+			if err != nil {
+				fail(i, err)
+				return
+			}
+			defer release()
+
+			run(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Politeness paces batch captures per target host. See the politeness
+// package for an implementation honoring robots.txt.
+type Politeness interface {
+	// Wait blocks until targetURL may be captured, or returns an error if
+	// it must not be captured.
+	Wait(ctx context.Context, targetURL string) error
+}
+
+// WithPoliteness sets a policy that every batch capture waits on before it
+// starts, e.g. to honor robots.txt and per-host crawl delays.
+func WithPoliteness(p Politeness) Option {
+	return func(c *Client) {
+		c.politeness = p
+	}
+}
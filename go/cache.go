@@ -0,0 +1,302 @@
+package screencraft
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the default maximum number of entries in a MemoryCache.
+const DefaultCacheSize = 256
+
+// ErrNotModified is returned by conditional captures (see WithIfNoneMatch)
+// when the page has not changed since the capture with the given ETag and
+// the client cache holds no result with that ETag to return instead.
+var ErrNotModified = errors.New("screencraft: not modified")
+
+// Cache stores capture results, keyed by the fingerprint of their options.
+//
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, if present and not expired.
+	Get(key string) (interface{}, bool)
+	// Set stores value under key for the given time to live.
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+// CacheInfo contains the HTTP caching metadata of a capture response.
+type CacheInfo struct {
+	// CacheControl is the Cache-Control header of the response.
+	CacheControl string
+	// ETag is the ETag header of the response.
+	ETag string
+	// FromCache reports whether the result was served from the client cache.
+	FromCache bool
+}
+
+// WithCache enables caching of synchronous capture results.
+//
+// Results are cached under the fingerprint of their options (see
+// OptionsFingerprint) for ttl. Use WithRespectCacheHeaders to let the
+// server's Cache-Control header decide the TTL instead.
+//
+// Results with an ETag are kept for another ttl after they expire. The next
+// capture with the same options sends the ETag as If-None-Match, and if the
+// API answers 304 Not Modified, the cached result is returned (with
+// FromCache set) without transferring or billing a new capture.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// WithRespectCacheHeaders makes the client cache honor the Cache-Control
+// header of capture responses: max-age sets the TTL, and no-store or no-cache
+// prevent caching. Responses without max-age use the TTL from WithCache.
+func WithRespectCacheHeaders(respect bool) Option {
+	return func(c *Client) {
+		c.respectCacheHeaders = respect
+	}
+}
+
+// cacheKey returns the cache key for a capture, or "" if caching is disabled.
+func (c *Client) cacheKey(kind string, opts interface{}) string {
+	if c.cache == nil {
+		return ""
+	}
+	fingerprint := OptionsFingerprint(opts)
+	if fingerprint == "" {
+		return ""
+	}
+	return kind + ":" + fingerprint
+}
+
+// cachedResult returns the cached value for key.
+func (c *Client) cachedResult(key string) (interface{}, bool) {
+	if key == "" {
+		return nil, false
+	}
+	return c.cache.Get(key)
+}
+
+// storeResult caches value under key, unless the cache info forbids it.
+func (c *Client) storeResult(key string, value interface{}, info CacheInfo) {
+	if key == "" {
+		return
+	}
+
+	ttl := c.cacheTTL
+	if c.respectCacheHeaders {
+		if hasCacheDirective(info.CacheControl, "no-store") {
+			return
+		}
+		maxAge, hasMaxAge, cacheable := parseCacheControl(info.CacheControl)
+		switch {
+		case !cacheable:
+			ttl = 0
+		case hasMaxAge:
+			ttl = maxAge
+		}
+	}
+
+	if ttl > 0 {
+		c.cache.Set(key, value, ttl)
+	}
+
+	// Results that can be revalidated outlive their TTL
+	if info.ETag != "" {
+		if window := max(ttl, c.cacheTTL); window > 0 {
+			c.cache.Set(staleKey(key), value, ttl+window)
+		}
+	}
+}
+
+// staleKey returns the key under which a result with an ETag is kept for
+// revalidation.
+func staleKey(key string) string {
+	return key + ":stale"
+}
+
+// withRevalidation makes a capture conditional on the ETag of the result
+// kept under key for revalidation, unless the call sets its own ETag.
+func (c *Client) withRevalidation(ctx context.Context, key string) context.Context {
+	if key == "" || callOptionsFrom(ctx).ifNoneMatch != "" {
+		return ctx
+	}
+	stale, ok := c.cache.Get(staleKey(key))
+	if !ok {
+		return ctx
+	}
+	etag := resultCacheInfo(stale).ETag
+	if etag == "" {
+		return ctx
+	}
+	return withCallOptions(ctx, []CallOption{WithIfNoneMatch(etag)})
+}
+
+// notModifiedResult returns the result kept under key for revalidation, if
+// its ETag is etag, so a 304 response can be answered with it.
+func (c *Client) notModifiedResult(key, etag string) (interface{}, bool) {
+	if key == "" || etag == "" {
+		return nil, false
+	}
+	stale, ok := c.cache.Get(staleKey(key))
+	if !ok || resultCacheInfo(stale).ETag != etag {
+		return nil, false
+	}
+	return stale, true
+}
+
+// resultCacheInfo returns the caching metadata of a cached result.
+func resultCacheInfo(v interface{}) CacheInfo {
+	switch r := v.(type) {
+	case *ScreenshotResult:
+		return r.CacheInfo
+	case *PDFResult:
+		return r.CacheInfo
+	}
+	return CacheInfo{}
+}
+
+// revalidatedCacheInfo returns the caching metadata of a result confirmed by
+// a 304 response with header h, which may update it.
+func revalidatedCacheInfo(info CacheInfo, h http.Header) CacheInfo {
+	if v := h.Get("Cache-Control"); v != "" {
+		info.CacheControl = v
+	}
+	if v := h.Get("ETag"); v != "" {
+		info.ETag = v
+	}
+	info.FromCache = false
+	return info
+}
+
+// cacheInfoFromHeader extracts the caching metadata of a response.
+func cacheInfoFromHeader(h http.Header) CacheInfo {
+	return CacheInfo{
+		CacheControl: h.Get("Cache-Control"),
+		ETag:         h.Get("ETag"),
+	}
+}
+
+// parseCacheControl parses a Cache-Control header value. It reports the
+// max-age directive, if any, and whether the response may be cached at all.
+func parseCacheControl(value string) (maxAge time.Duration, hasMaxAge, cacheable bool) {
+	cacheable = true
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			cacheable = false
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(arg, `"`))
+			if err != nil {
+				continue
+			}
+			maxAge = time.Duration(seconds) * time.Second
+			hasMaxAge = true
+		}
+	}
+	if hasMaxAge && maxAge <= 0 {
+		cacheable = false
+	}
+	return maxAge, hasMaxAge, cacheable
+}
+
+// hasCacheDirective reports whether a Cache-Control header value contains
+// the given directive.
+func hasCacheDirective(value, directive string) bool {
+	for _, d := range strings.Split(value, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(d), "=")
+		if strings.EqualFold(name, directive) {
+			return true
+		}
+	}
+	return false
+}
+
+// MemoryCache is an in-memory LRU Cache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// memoryCacheEntry is a value stored in a MemoryCache.
+type memoryCacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// NewMemoryCache creates an in-memory LRU cache holding at most maxEntries
+// values. If maxEntries is not positive, DefaultCacheSize is used.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithCache(screencraft.NewMemoryCache(100), 10*time.Minute),
+//	    screencraft.WithRespectCacheHeaders(true),
+//	)
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheSize
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value stored under key, if present and not expired.
+func (m *MemoryCache) Get(key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value under key for the given time to live, evicting the least
+// recently used entry if the cache is full.
+func (m *MemoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expires = expires
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+
+	for m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
@@ -0,0 +1,142 @@
+package screencraft
+
+import (
+	"context"
+	"time"
+)
+
+// CallOption configures a single API call.
+type CallOption func(*callOptions)
+
+// callOptions holds per-call settings.
+type callOptions struct {
+	// priority is the scheduling priority of the call.
+	priority Priority
+
+	// timeout overrides the client's HTTP timeout, if positive.
+	timeout time.Duration
+
+	// maxRetries overrides the client's maximum number of retries, if set.
+	maxRetries *int
+
+	// ifNoneMatch is sent as the If-None-Match header, if set.
+	ifNoneMatch string
+
+	// idempotencyKey is sent as the Idempotency-Key header, if set.
+	idempotencyKey string
+
+	// headers are extra request headers.
+	headers map[string]string
+
+	// skipQuotaPacing exempts the call from quota pacing.
+	skipQuotaPacing bool
+}
+
+// callOptionsKey is the context key for per-call settings.
+type callOptionsKey struct{}
+
+// WithPriority sets the scheduling priority of a call. Higher-priority calls
+// are dispatched first when requests queue for the client's limits.
+//
+// Example:
+//
+//	result, err := client.Screenshot(ctx, opts, screencraft.WithPriority(screencraft.PriorityHigh))
+func WithPriority(p Priority) CallOption {
+	return func(o *callOptions) {
+		o.priority = p
+	}
+}
+
+// WithCallTimeout overrides the client's HTTP timeout (see WithTimeout) for
+// each attempt of a call. Use it for captures that take much longer or
+// shorter than usual, e.g. full-page PDFs.
+//
+// Example:
+//
+//	result, err := client.PDF(ctx, opts, screencraft.WithCallTimeout(3*time.Minute))
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallRetries overrides the client's maximum number of retries (see
+// WithMaxRetries) for a call. Use 0 to disable retries.
+//
+// Example:
+//
+//	result, err := client.Screenshot(ctx, opts, screencraft.WithCallRetries(0))
+func WithCallRetries(maxRetries int) CallOption {
+	return func(o *callOptions) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+		o.maxRetries = &maxRetries
+	}
+}
+
+// WithIfNoneMatch makes a capture conditional on the ETag of a previous
+// capture (see CacheInfo.ETag). If the page is unchanged, the API answers
+// 304 Not Modified without transferring or billing a new capture. The call
+// then returns the cached result with that ETag if the client has one (see
+// WithCache), and ErrNotModified otherwise, so the previous result can be
+// reused.
+//
+// With WithCache, expired results are revalidated automatically, so the
+// option is only needed for results kept outside the client.
+//
+// Example:
+//
+//	result, err := client.Screenshot(ctx, opts, screencraft.WithIfNoneMatch(previous.ETag))
+//	if errors.Is(err, screencraft.ErrNotModified) {
+//	    result = previous
+//	} else if err != nil {
+//	    log.Fatal(err)
+//	}
+func WithIfNoneMatch(etag string) CallOption {
+	return func(o *callOptions) {
+		o.ifNoneMatch = etag
+	}
+}
+
+// withIdempotencyKey sends key as the Idempotency-Key header of a call, so
+// the API processes repeated submissions only once.
+func withIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// withHeader sends an extra request header with a call.
+func withHeader(name, value string) CallOption {
+	return func(o *callOptions) {
+		headers := make(map[string]string, len(o.headers)+1)
+		for k, v := range o.headers {
+			headers[k] = v
+		}
+		headers[name] = value
+		o.headers = headers
+	}
+}
+
+// withCallOptions returns a context carrying the per-call settings, applied
+// on top of any settings already in ctx.
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	o := callOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// callOptionsFrom returns the per-call settings carried by ctx.
+func callOptionsFrom(ctx context.Context) callOptions {
+	if o, ok := ctx.Value(callOptionsKey{}).(callOptions); ok {
+		return o
+	}
+	return callOptions{priority: PriorityNormal}
+}
@@ -0,0 +1,116 @@
+package screencraft
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for the client's waits: retry backoff,
+// Retry-After handling, rate limiting, adaptive throttling and failover
+// cooldowns. Implementations must be safe for concurrent use.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+// Now implements Clock.
+func (systemClock) Now() time.Time { return time.Now() }
+
+// After implements Clock.
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// WithClock replaces the wall clock used for retry and rate limit waits, so
+// tests of retry behavior can advance time instantly instead of sleeping. A
+// nil clock restores the wall clock.
+//
+// Example:
+//
+//	// fakeClock's After advances its time and fires immediately.
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithClock(fakeClock),
+//	    screencraft.WithRandSource(rand.NewSource(1)),
+//	)
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		if clock == nil {
+			clock = systemClock{}
+		}
+		c.clock = clock
+	}
+}
+
+// WithRandSource sets the source of the jitter added to retry backoff, so
+// waits are deterministic in tests. It applies to the default policy and to
+// ExponentialRetryPolicy values without a Jitter function. A nil source
+// restores the global math/rand source.
+func WithRandSource(src rand.Source) Option {
+	return func(c *Client) {
+		if src == nil {
+			c.rand = nil
+			return
+		}
+		c.rand = &lockedRand{r: rand.New(src)}
+	}
+}
+
+// lockedRand makes a rand.Rand safe for concurrent use.
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (l *lockedRand) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
+
+// now returns the current time according to the client's clock.
+func (c *Client) now() time.Time {
+	return c.clock.Now()
+}
+
+// sleep waits for d according to the client's clock, or until ctx is done.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	// Avoid leaving a pending timer behind when ctx is canceled first.
+	if _, ok := c.clock.(systemClock); ok {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case <-c.clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// jitter returns a pseudo-random number in [0.0, 1.0) from the client's
+// random source.
+func (c *Client) jitter() float64 {
+	if c.rand != nil {
+		return c.rand.Float64()
+	}
+	return rand.Float64()
+}
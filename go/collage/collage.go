@@ -0,0 +1,207 @@
+// Package collage assembles multiple screenshots into a labeled grid image.
+//
+// It produces review sheets such as devices × pages locally, without any
+// external image tooling.
+//
+// Basic usage:
+//
+//	img, err := collage.Grid([][]*screencraft.ScreenshotResult{
+//	    {homeDesktop, homeMobile},
+//	    {pricingDesktop, pricingMobile},
+//	}, &collage.Options{
+//	    RowLabels:    []string{"Home", "Pricing"},
+//	    ColumnLabels: []string{"Desktop", "Mobile"},
+//	    CellWidth:    400,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	f, _ := os.Create("review.png")
+//	defer f.Close()
+//	png.Encode(f, img)
+package collage
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/internal/imageutil"
+)
+
+const (
+	// DefaultCellWidth is the default width of each grid cell in pixels.
+	DefaultCellWidth = 320
+
+	// DefaultPadding is the default spacing around cells in pixels.
+	DefaultPadding = 16
+
+	// DefaultLabelScale is the default label font scale (7 pixels per unit).
+	DefaultLabelScale = 2
+)
+
+// ErrEmpty is returned when the grid contains no screenshots.
+var ErrEmpty = errors.New("collage: no screenshots to assemble")
+
+// Options configures the collage layout.
+type Options struct {
+	// RowLabels are drawn to the left of each row.
+	RowLabels []string
+	// ColumnLabels are drawn above each column.
+	ColumnLabels []string
+	// CellWidth is the width each screenshot is scaled to. Heights keep the
+	// aspect ratio. Defaults to DefaultCellWidth.
+	CellWidth int
+	// MaxCellHeight crops tall (e.g. full-page) screenshots. Zero means no limit.
+	MaxCellHeight int
+	// Padding is the spacing around cells. Defaults to DefaultPadding; a
+	// negative value disables padding.
+	Padding int
+	// LabelScale is the label font scale. Defaults to DefaultLabelScale.
+	LabelScale int
+	// Background is the sheet background. Defaults to white.
+	Background color.Color
+	// LabelColor is the label text color. Defaults to black.
+	LabelColor color.Color
+}
+
+// Grid arranges screenshots into a grid with rows[i][j] in row i, column j.
+// Nil entries leave an empty cell.
+func Grid(rows [][]*screencraft.ScreenshotResult, opts *Options) (*image.RGBA, error) {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.CellWidth <= 0 {
+		o.CellWidth = DefaultCellWidth
+	}
+	if o.Padding == 0 {
+		o.Padding = DefaultPadding
+	} else if o.Padding < 0 {
+		o.Padding = 0
+	}
+	if o.LabelScale <= 0 {
+		o.LabelScale = DefaultLabelScale
+	}
+	if o.Background == nil {
+		o.Background = color.White
+	}
+	if o.LabelColor == nil {
+		o.LabelColor = color.Black
+	}
+
+	// Decode and scale all cells
+	cols := 0
+	cells := make([][]image.Image, len(rows))
+	for i, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+		cells[i] = make([]image.Image, len(row))
+		for j, result := range row {
+			if result == nil || len(result.Data) == 0 {
+				continue
+			}
+			img, err := imageutil.Decode(result.Data)
+			if err != nil {
+				return nil, fmt.Errorf("collage: cell (%d, %d): %w", i, j, err)
+			}
+			cells[i][j] = scaleToWidth(img, o.CellWidth, o.MaxCellHeight)
+		}
+	}
+	if cols == 0 {
+		return nil, ErrEmpty
+	}
+
+	// Compute row heights and label sizes
+	rowHeights := make([]int, len(rows))
+	for i := range cells {
+		for _, img := range cells[i] {
+			if img != nil && img.Bounds().Dy() > rowHeights[i] {
+				rowHeights[i] = img.Bounds().Dy()
+			}
+		}
+		if rowHeights[i] == 0 {
+			rowHeights[i] = imageutil.TextHeight(o.LabelScale)
+		}
+	}
+
+	labelWidth := 0
+	for _, label := range o.RowLabels {
+		if w := imageutil.TextWidth(label, o.LabelScale); w > labelWidth {
+			labelWidth = w
+		}
+	}
+	if labelWidth > 0 {
+		labelWidth += o.Padding
+	}
+
+	headerHeight := 0
+	if len(o.ColumnLabels) > 0 {
+		headerHeight = imageutil.TextHeight(o.LabelScale) + o.Padding
+	}
+
+	width := o.Padding + labelWidth + cols*(o.CellWidth+o.Padding)
+	height := o.Padding + headerHeight
+	for _, h := range rowHeights {
+		height += h + o.Padding
+	}
+
+	sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+	imageutil.Fill(sheet, sheet.Bounds(), o.Background)
+
+	// Column labels
+	for j, label := range o.ColumnLabels {
+		if j >= cols {
+			break
+		}
+		x := o.Padding + labelWidth + j*(o.CellWidth+o.Padding)
+		x += (o.CellWidth - imageutil.TextWidth(label, o.LabelScale)) / 2
+		imageutil.DrawText(sheet, x, o.Padding, label, o.LabelScale, o.LabelColor)
+	}
+
+	// Rows
+	y := o.Padding + headerHeight
+	for i := range cells {
+		if i < len(o.RowLabels) {
+			ly := y + (rowHeights[i]-imageutil.TextHeight(o.LabelScale))/2
+			imageutil.DrawText(sheet, o.Padding, ly, o.RowLabels[i], o.LabelScale, o.LabelColor)
+		}
+
+		for j, img := range cells[i] {
+			if img == nil {
+				continue
+			}
+			x := o.Padding + labelWidth + j*(o.CellWidth+o.Padding)
+			r := image.Rect(x, y, x+img.Bounds().Dx(), y+img.Bounds().Dy())
+			draw.Draw(sheet, r, img, img.Bounds().Min, draw.Over)
+		}
+
+		y += rowHeights[i] + o.Padding
+	}
+
+	return sheet, nil
+}
+
+// scaleToWidth scales img to the given width, keeping the aspect ratio, and
+// crops it to maxHeight if positive.
+func scaleToWidth(img image.Image, width, maxHeight int) image.Image {
+	b := img.Bounds()
+	if b.Dx() == 0 {
+		return img
+	}
+
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+	scaled := imageutil.Resize(img, width, height)
+
+	if maxHeight > 0 && height > maxHeight {
+		return scaled.SubImage(image.Rect(0, 0, width, maxHeight))
+	}
+	return scaled
+}
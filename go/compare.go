@@ -0,0 +1,98 @@
+package screencraft
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/DancingTedDanson011/screencraft-go/internal/imageutil"
+)
+
+// DefaultDiffThreshold is the default perceptual color difference (0-1)
+// above which CompareImages counts two pixels as different.
+const DefaultDiffThreshold = 0.1
+
+// DiffOptions configures CompareImages.
+type DiffOptions struct {
+	// Threshold is the perceptual color difference (0-1) above which two
+	// pixels count as different. Lower values are stricter. Defaults to
+	// DefaultDiffThreshold.
+	Threshold float64
+
+	// DiffImage generates a PNG highlighting differing pixels in red.
+	DiffImage bool
+}
+
+// DiffResult is the result of comparing two images.
+type DiffResult struct {
+	// MismatchPercent is the percentage (0-100) of differing pixels.
+	MismatchPercent float64
+
+	// DiffPixels is the number of differing pixels.
+	DiffPixels int
+
+	// Bounds is the smallest rectangle containing all differing pixels.
+	Bounds image.Rectangle
+
+	// SizeMismatch is true if the images have different dimensions. The
+	// images are then compared from their top-left corners and the pixels
+	// covered by only one of them count as differing.
+	SizeMismatch bool
+
+	// DiffImage is a PNG highlighting differing pixels in red, if
+	// DiffOptions.DiffImage is set and the images differ.
+	DiffImage []byte
+}
+
+// Equal reports whether no pixels differ.
+func (r *DiffResult) Equal() bool {
+	return r.DiffPixels == 0
+}
+
+// CompareImages compares two PNG or JPEG images locally using a perceptual
+// color difference, e.g. to detect changes between scheduled captures.
+//
+// Example:
+//
+//	diff, err := screencraft.CompareImages(previous.Data, current.Data, screencraft.DiffOptions{
+//	    DiffImage: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if diff.MismatchPercent > 1 {
+//	    os.WriteFile("diff.png", diff.DiffImage, 0o644)
+//	}
+func CompareImages(a, b []byte, opts DiffOptions) (*DiffResult, error) {
+	imgA, err := imageutil.Decode(a)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: first image: %w", err)
+	}
+	imgB, err := imageutil.Decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: second image: %w", err)
+	}
+
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultDiffThreshold
+	}
+
+	d := imageutil.Compare(imgA, imgB, threshold, opts.DiffImage)
+	result := &DiffResult{
+		MismatchPercent: d.Ratio * 100,
+		DiffPixels:      d.Pixels,
+		Bounds:          d.Bounds,
+		SizeMismatch:    d.SizeMismatch,
+	}
+
+	if d.Image != nil {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, d.Image); err != nil {
+			return nil, fmt.Errorf("screencraft: failed to encode diff image: %w", err)
+		}
+		result.DiffImage = buf.Bytes()
+	}
+	return result, nil
+}
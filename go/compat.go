@@ -0,0 +1,141 @@
+package screencraft
+
+import "net/http"
+
+// CompatibilityMode selects the API dialect spoken by the client.
+type CompatibilityMode int
+
+const (
+	// SaaS is the hosted ScreenCraft API (default).
+	SaaS CompatibilityMode = iota
+	// Enterprise is the self-hosted ScreenCraft appliance.
+	Enterprise
+)
+
+// enterpriseAPIKeyHeader is the authentication header used by the appliance.
+const enterpriseAPIKeyHeader = "X-API-Key"
+
+// enterprisePaths maps hosted API endpoints to their appliance equivalents.
+var enterprisePaths = map[string]string{
+	screenshotEndpoint: "/capture/screenshot",
+	pdfEndpoint:        "/capture/pdf",
+	auditEndpoint:      "/capture/audit",
+	linksEndpoint:      "/capture/links",
+}
+
+// WithCompatibilityMode selects the API dialect. Use Enterprise for the
+// self-hosted appliance, which authenticates with an X-API-Key header, serves
+// capture endpoints under /capture and reports errors in a flat schema.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithBaseURL("https://screencraft.internal.example.com/api"),
+//	    screencraft.WithCompatibilityMode(screencraft.Enterprise),
+//	)
+func WithCompatibilityMode(mode CompatibilityMode) Option {
+	return func(c *Client) {
+		c.compatibilityMode = mode
+	}
+}
+
+// endpointPath returns the path of an endpoint in the client's API dialect.
+func (c *Client) endpointPath(endpoint string) string {
+	if c.compatibilityMode == Enterprise {
+		if path, ok := enterprisePaths[endpoint]; ok {
+			return path
+		}
+	}
+	return endpoint
+}
+
+// AuthScheme selects how the API key is sent with requests.
+type AuthScheme int
+
+const (
+	// AuthBearer sends the key as "Authorization: Bearer <key>". It is the
+	// default for the hosted API.
+	AuthBearer AuthScheme = iota + 1
+	// AuthHeaderXAPIKey sends the key in an X-API-Key header. It is the
+	// default in Enterprise mode.
+	AuthHeaderXAPIKey
+	// AuthQueryParam sends the key as the api_key query parameter. URLs may
+	// end up in proxy and server logs, so use it only with gateways that
+	// accept no header.
+	AuthQueryParam
+)
+
+// apiKeyQueryParam is the query parameter used by AuthQueryParam.
+const apiKeyQueryParam = "api_key"
+
+// WithAuthScheme overrides how the API key is sent, for gateways and
+// self-hosted deployments that expect it elsewhere than the API dialect's
+// default (see WithCompatibilityMode).
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithBaseURL("https://gateway.example.com/screencraft"),
+//	    screencraft.WithAuthScheme(screencraft.AuthHeaderXAPIKey),
+//	)
+func WithAuthScheme(scheme AuthScheme) Option {
+	return func(c *Client) {
+		c.authScheme = scheme
+	}
+}
+
+// setAuthHeader authenticates req with apiKey using the client's auth scheme,
+// or the default of its API dialect.
+func (c *Client) setAuthHeader(req *http.Request, apiKey string) {
+	scheme := c.authScheme
+	if scheme == 0 {
+		scheme = AuthBearer
+		if c.compatibilityMode == Enterprise {
+			scheme = AuthHeaderXAPIKey
+		}
+	}
+
+	switch scheme {
+	case AuthHeaderXAPIKey:
+		req.Header.Set(enterpriseAPIKeyHeader, apiKey)
+	case AuthQueryParam:
+		query := req.URL.Query()
+		query.Set(apiKeyQueryParam, apiKey)
+		req.URL.RawQuery = query.Encode()
+	default:
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
+
+// enterpriseErrorResponse is the appliance's error schema.
+type enterpriseErrorResponse struct {
+	Error   string                 `json:"error"`
+	Code    string                 `json:"code,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// decodeErrorBody decodes an error response body in the client's API dialect
+// into the hosted API schema. Error bodies are never decoded strictly, so
+// extra fields do not hide the error type and retry hints.
+func (c *Client) decodeErrorBody(body []byte, apiResp *APIResponse) error {
+	if c.compatibilityMode != Enterprise {
+		return decodeJSONValue(body, apiResp, false)
+	}
+
+	var entResp enterpriseErrorResponse
+	if err := decodeJSONValue(body, &entResp, false); err != nil {
+		// Some appliance versions already use the hosted schema
+		if decodeJSONValue(body, apiResp, false) == nil && apiResp.Error != nil {
+			return nil
+		}
+		return err
+	}
+
+	apiResp.Success = false
+	apiResp.Error = &APIErrorDetails{
+		Code:    entResp.Code,
+		Message: entResp.Error,
+		Details: entResp.Details,
+	}
+	return nil
+}
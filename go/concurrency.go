@@ -0,0 +1,35 @@
+package screencraft
+
+import (
+	"io"
+	"sync"
+)
+
+// WithMaxConcurrentRequests limits how many requests the client has in
+// flight at once, e.g. to stay within the plan's concurrency limit. A request
+// holds its slot until its response body is closed; callers over the limit
+// wait in priority order (see WithPriority) and give up when their context
+// is done. Zero or less means unlimited.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithMaxConcurrentRequests(4))
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) {
+		c.requestScheduler.setCapacity(n)
+	}
+}
+
+// releasingBody releases a concurrency slot when the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the body and releases the slot.
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
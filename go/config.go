@@ -0,0 +1,465 @@
+package screencraft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewFromEnv.
+const (
+	envAPIKey            = "SCREENCRAFT_API_KEY"
+	envBaseURL           = "SCREENCRAFT_BASE_URL"
+	envTimeout           = "SCREENCRAFT_TIMEOUT"
+	envMaxRetries        = "SCREENCRAFT_MAX_RETRIES"
+	envUserAgent         = "SCREENCRAFT_USER_AGENT"
+	envDebug             = "SCREENCRAFT_DEBUG"
+	envCompatibilityMode = "SCREENCRAFT_COMPATIBILITY_MODE"
+	envAuthScheme        = "SCREENCRAFT_AUTH_SCHEME"
+	envProfile           = "SCREENCRAFT_PROFILE"
+)
+
+// ErrProfileNotFound is returned when a config file has no profile of the
+// requested name.
+var ErrProfileNotFound = errors.New("screencraft: config profile not found")
+
+// defaultProfile is the profile NewFromConfig uses unless SCREENCRAFT_PROFILE
+// is set.
+const defaultProfile = "default"
+
+// Config holds client settings loaded from the environment or a config file.
+// Empty fields keep the client defaults.
+type Config struct {
+	// APIKey is the API key.
+	APIKey string `json:"apiKey,omitempty"`
+	// BaseURL is the API base URL.
+	BaseURL string `json:"baseUrl,omitempty"`
+	// Timeout is the HTTP timeout as a duration such as "30s", or a number
+	// of seconds.
+	Timeout string `json:"timeout,omitempty"`
+	// MaxRetries is the maximum number of retries.
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// UserAgent is the User-Agent header.
+	UserAgent string `json:"userAgent,omitempty"`
+	// Debug enables debug logging.
+	Debug bool `json:"debug,omitempty"`
+	// CompatibilityMode is "saas" or "enterprise".
+	CompatibilityMode string `json:"compatibilityMode,omitempty"`
+	// AuthScheme is "bearer", "x-api-key" or "query".
+	AuthScheme string `json:"authScheme,omitempty"`
+}
+
+// UnmarshalJSON decodes settings, accepting a number of seconds as Timeout.
+func (cfg *Config) UnmarshalJSON(data []byte) error {
+	type config Config
+	aux := struct {
+		*config
+		Timeout json.RawMessage `json:"timeout,omitempty"`
+	}{config: (*config)(cfg)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.Timeout) > 0 && string(aux.Timeout) != "null" {
+		if err := json.Unmarshal(aux.Timeout, &cfg.Timeout); err != nil {
+			cfg.Timeout = string(aux.Timeout)
+		}
+	}
+	return nil
+}
+
+// configFile is the layout of a config file: settings shared by all
+// profiles, and named profiles that override them.
+type configFile struct {
+	Config   Config
+	Profiles map[string]Config
+}
+
+// NewFromEnv creates a client configured by environment variables:
+//
+//	SCREENCRAFT_API_KEY             API key (required)
+//	SCREENCRAFT_BASE_URL            API base URL
+//	SCREENCRAFT_TIMEOUT             HTTP timeout, e.g. "30s" or "30"
+//	SCREENCRAFT_MAX_RETRIES         maximum number of retries
+//	SCREENCRAFT_USER_AGENT          User-Agent header
+//	SCREENCRAFT_DEBUG               "true" to enable debug logging
+//	SCREENCRAFT_COMPATIBILITY_MODE  "saas" or "enterprise"
+//	SCREENCRAFT_AUTH_SCHEME         "bearer", "x-api-key" or "query"
+//
+// opts are applied after the environment, so they take precedence.
+//
+// Example:
+//
+//	client, err := screencraft.NewFromEnv(screencraft.WithLogger(logger))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewFromEnv(opts ...Option) (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.New(opts...)
+}
+
+// ConfigFromEnv reads client settings from the environment variables listed
+// at NewFromEnv.
+func ConfigFromEnv() (*Config, error) {
+	cfg := &Config{
+		APIKey:            os.Getenv(envAPIKey),
+		BaseURL:           os.Getenv(envBaseURL),
+		Timeout:           os.Getenv(envTimeout),
+		UserAgent:         os.Getenv(envUserAgent),
+		CompatibilityMode: os.Getenv(envCompatibilityMode),
+		AuthScheme:        os.Getenv(envAuthScheme),
+	}
+
+	if v := os.Getenv(envMaxRetries); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, NewValidationError(envMaxRetries, "must be an integer", "format").Error
+		}
+		cfg.MaxRetries = &n
+	}
+
+	if v := os.Getenv(envDebug); v != "" {
+		debug, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, NewValidationError(envDebug, "must be true or false", "format").Error
+		}
+		cfg.Debug = debug
+	}
+
+	return cfg, nil
+}
+
+// NewFromConfig creates a client configured by a JSON or YAML file. Settings
+// at the top level apply to every profile; the profile named by
+// SCREENCRAFT_PROFILE, or "default" if the file has one, overrides them.
+//
+//	{
+//	    "timeout": "60s",
+//	    "profiles": {
+//	        "default": {"apiKey": "sk_live_..."},
+//	        "staging": {"apiKey": "sk_test_...", "baseUrl": "https://staging.example.com/api/v1"}
+//	    }
+//	}
+//
+// Files ending in .yaml or .yml are read as YAML. Only block mappings with
+// scalar values and comments are supported, which covers every setting.
+//
+// opts are applied after the file, so they take precedence.
+//
+// Example:
+//
+//	client, err := screencraft.NewFromConfig("/etc/myapp/screencraft.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewFromConfig(path string, opts ...Option) (*Client, error) {
+	file, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg *Config
+	if name := os.Getenv(envProfile); name != "" {
+		var ok bool
+		if cfg, ok = file.profile(name); !ok {
+			return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, path)
+		}
+	} else {
+		cfg, _ = file.profile(defaultProfile)
+	}
+	return cfg.New(opts...)
+}
+
+// LoadConfig reads the settings of a profile from a JSON or YAML config file
+// (see NewFromConfig). An empty profile returns the top-level settings.
+func LoadConfig(path, profile string) (*Config, error) {
+	file, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		cfg := file.Config
+		return &cfg, nil
+	}
+	cfg, ok := file.profile(profile)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, profile, path)
+	}
+	return cfg, nil
+}
+
+// readConfigFile reads and parses a config file.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read config: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("screencraft: failed to parse config %s: %w", path, err)
+		}
+	}
+
+	var file configFile
+	var profiles struct {
+		Profiles map[string]Config `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &file.Config); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse config %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse config %s: %w", path, err)
+	}
+	file.Profiles = profiles.Profiles
+	return &file, nil
+}
+
+// profile returns the top-level settings overridden by the named profile,
+// and whether the profile exists.
+func (f *configFile) profile(name string) (*Config, bool) {
+	cfg := f.Config
+	override, ok := f.Profiles[name]
+	if ok {
+		cfg.merge(&override)
+	}
+	return &cfg, ok
+}
+
+// merge overrides the settings of cfg with the non-empty settings of o.
+func (cfg *Config) merge(o *Config) {
+	if o.APIKey != "" {
+		cfg.APIKey = o.APIKey
+	}
+	if o.BaseURL != "" {
+		cfg.BaseURL = o.BaseURL
+	}
+	if o.Timeout != "" {
+		cfg.Timeout = o.Timeout
+	}
+	if o.MaxRetries != nil {
+		cfg.MaxRetries = o.MaxRetries
+	}
+	if o.UserAgent != "" {
+		cfg.UserAgent = o.UserAgent
+	}
+	if o.Debug {
+		cfg.Debug = true
+	}
+	if o.CompatibilityMode != "" {
+		cfg.CompatibilityMode = o.CompatibilityMode
+	}
+	if o.AuthScheme != "" {
+		cfg.AuthScheme = o.AuthScheme
+	}
+}
+
+// New creates a client with the settings of cfg, followed by opts. It
+// returns ErrMissingAPIKey if neither cfg nor opts provide credentials.
+func (cfg *Config) New(opts ...Option) (*Client, error) {
+	cfgOpts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	c := New(cfg.APIKey, append(cfgOpts, opts...)...)
+	if c.apiKey == "" && c.credentials == nil {
+		return nil, ErrMissingAPIKey
+	}
+	return c, nil
+}
+
+// Options returns the client options for the settings of cfg, except the
+// API key.
+func (cfg *Config) Options() ([]Option, error) {
+	var opts []Option
+
+	if cfg.BaseURL != "" {
+		opts = append(opts, WithBaseURL(strings.TrimRight(cfg.BaseURL, "/")))
+	}
+
+	if cfg.Timeout != "" {
+		timeout, err := parseConfigDuration(cfg.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, NewValidationError("timeout", fmt.Sprintf("invalid duration %q", cfg.Timeout), "format").Error
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+
+	if cfg.MaxRetries != nil {
+		if *cfg.MaxRetries < 0 {
+			return nil, NewValidationError("maxRetries", "must not be negative", "range").Error
+		}
+		opts = append(opts, WithMaxRetries(*cfg.MaxRetries))
+	}
+
+	if cfg.UserAgent != "" {
+		opts = append(opts, WithUserAgent(cfg.UserAgent))
+	}
+
+	if cfg.Debug {
+		opts = append(opts, WithDebug(true))
+	}
+
+	switch strings.ToLower(cfg.CompatibilityMode) {
+	case "":
+	case "saas":
+		opts = append(opts, WithCompatibilityMode(SaaS))
+	case "enterprise":
+		opts = append(opts, WithCompatibilityMode(Enterprise))
+	default:
+		return nil, NewValidationError("compatibilityMode", fmt.Sprintf("unknown compatibility mode %q", cfg.CompatibilityMode), "enum").Error
+	}
+
+	switch strings.ToLower(cfg.AuthScheme) {
+	case "":
+	case "bearer":
+		opts = append(opts, WithAuthScheme(AuthBearer))
+	case "x-api-key":
+		opts = append(opts, WithAuthScheme(AuthHeaderXAPIKey))
+	case "query":
+		opts = append(opts, WithAuthScheme(AuthQueryParam))
+	default:
+		return nil, NewValidationError("authScheme", fmt.Sprintf("unknown auth scheme %q", cfg.AuthScheme), "enum").Error
+	}
+
+	return opts, nil
+}
+
+// parseConfigDuration parses a duration such as "30s", or a number of
+// seconds.
+func parseConfigDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// yamlToJSON converts a YAML document made of block mappings with scalar
+// values to JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	type frame struct {
+		indent int
+		m      map[string]interface{}
+	}
+
+	root := map[string]interface{}{}
+	stack := []frame{{indent: -1, m: root}}
+	pending := "" // key whose value is a mapping if the next line is indented
+	pendingIndent := 0
+
+	for n, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(stripYAMLComment(line))
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		content := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(content, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", n+1)
+		}
+		indent := len(line) - len(content)
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok || strings.HasPrefix(trimmed, "- ") {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", n+1)
+		}
+		key = unquoteYAML(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if pending != "" {
+			if indent > pendingIndent {
+				m := map[string]interface{}{}
+				stack[len(stack)-1].m[pending] = m
+				stack = append(stack, frame{indent: indent, m: m})
+			} else {
+				stack[len(stack)-1].m[pending] = nil
+			}
+			pending = ""
+		}
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		top := &stack[len(stack)-1]
+		if top.indent < 0 {
+			top.indent = indent
+		}
+		if indent != top.indent {
+			return nil, fmt.Errorf("line %d: inconsistent indentation", n+1)
+		}
+
+		if value == "" {
+			pending, pendingIndent = key, indent
+			continue
+		}
+		top.m[key] = yamlScalar(value)
+	}
+	if pending != "" {
+		stack[len(stack)-1].m[pending] = nil
+	}
+
+	return json.Marshal(root)
+}
+
+// stripYAMLComment removes a trailing comment from a line.
+func stripYAMLComment(line string) string {
+	inQuote := byte(0)
+	for i := 0; i < len(line); i++ {
+		switch ch := line[i]; {
+		case inQuote != 0:
+			if ch == inQuote {
+				inQuote = 0
+			}
+		case ch == '"' || ch == '\'':
+			inQuote = ch
+		case ch == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// yamlScalar converts a YAML scalar to a JSON value.
+func yamlScalar(s string) interface{} {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return unquoteYAML(s)
+	}
+	switch s {
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case "null", "~":
+		return nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+	return s
+}
+
+// unquoteYAML removes the quotes around a quoted YAML string.
+func unquoteYAML(s string) string {
+	if len(s) < 2 || s[0] != s[len(s)-1] {
+		return s
+	}
+	switch s[0] {
+	case '"':
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	case '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
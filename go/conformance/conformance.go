@@ -0,0 +1,305 @@
+// Package conformance provides a compatibility harness for ScreenCraft
+// clients and API deployments.
+//
+// The scenarios exercise the core API surface (full-page and clipped
+// screenshots, async captures with webhooks, concurrent batches, PDF headers
+// and footers, and retries under rate limiting). They double as runnable
+// examples of the SDK.
+//
+// Run the harness against the fake server of the screencrafttest package:
+//
+//	func TestConformance(t *testing.T) {
+//	    srv := screencrafttest.NewServer()
+//	    defer srv.Close()
+//
+//	    client := screencraft.New("test-key",
+//	        screencraft.WithBaseURL(srv.URL),
+//	        screencraft.WithRetryWait(time.Millisecond, 10*time.Millisecond),
+//	    )
+//	    conformance.Conformance(t, client, conformance.WithServer(srv))
+//	}
+//
+// Without WithServer, the scenarios run against whatever API the client
+// targets (e.g. a self-hosted build); scenarios that need failure injection
+// are skipped.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/screencrafttest"
+)
+
+// Client is the client surface exercised by the harness. *screencraft.Client
+// implements it; alternative implementations can be verified as well.
+type Client interface {
+	Screenshot(ctx context.Context, opts *screencraft.ScreenshotOptions, callOpts ...screencraft.CallOption) (*screencraft.ScreenshotResult, error)
+	ScreenshotAsync(ctx context.Context, opts *screencraft.ScreenshotOptions, callOpts ...screencraft.CallOption) (string, error)
+	PDF(ctx context.Context, opts *screencraft.PDFOptions, callOpts ...screencraft.CallOption) (*screencraft.PDFResult, error)
+}
+
+var _ Client = (*screencraft.Client)(nil)
+
+// Option configures the harness.
+type Option func(*config)
+
+type config struct {
+	server    *screencrafttest.Server
+	targetURL string
+	timeout   time.Duration
+}
+
+// WithServer enables scenarios that inspect requests or inject failures.
+// The client must target the server's URL.
+func WithServer(srv *screencrafttest.Server) Option {
+	return func(c *config) {
+		c.server = srv
+	}
+}
+
+// WithTargetURL sets the page URL captured by the scenarios.
+// Defaults to https://example.com.
+func WithTargetURL(url string) Option {
+	return func(c *config) {
+		c.targetURL = url
+	}
+}
+
+// WithScenarioTimeout sets the timeout of each scenario. Defaults to 2 minutes.
+func WithScenarioTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// Scenario is a single conformance check.
+type Scenario struct {
+	// Name is the subtest name.
+	Name string
+	// NeedsServer is true if the scenario requires the fake server.
+	NeedsServer bool
+	// Run executes the scenario.
+	Run func(ctx context.Context, t *testing.T, client Client, env *Env)
+}
+
+// Env is the environment passed to scenarios.
+type Env struct {
+	// Server is the fake server, or nil when running against a real API.
+	Server *screencrafttest.Server
+	// TargetURL is the page URL to capture.
+	TargetURL string
+}
+
+// Scenarios returns all conformance scenarios in execution order.
+func Scenarios() []Scenario {
+	return []Scenario{
+		{Name: "FullPage", Run: fullPage},
+		{Name: "Clip", Run: clip},
+		{Name: "AsyncWebhook", Run: asyncWebhook},
+		{Name: "Batch", Run: batch},
+		{Name: "PDFHeaderFooter", Run: pdfHeaderFooter},
+		{Name: "RetryOn429", NeedsServer: true, Run: retryOn429},
+	}
+}
+
+// Conformance runs all scenarios as subtests of t.
+func Conformance(t *testing.T, client Client, opts ...Option) {
+	t.Helper()
+
+	cfg := &config{
+		targetURL: "https://example.com",
+		timeout:   2 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	env := &Env{Server: cfg.server, TargetURL: cfg.targetURL}
+	for _, sc := range Scenarios() {
+		sc := sc
+		t.Run(sc.Name, func(t *testing.T) {
+			if sc.NeedsServer && env.Server == nil {
+				t.Skip("requires the fake server (see WithServer)")
+			}
+			if env.Server != nil {
+				env.Server.Reset()
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+			defer cancel()
+			sc.Run(ctx, t, client, env)
+		})
+	}
+}
+
+func fullPage(ctx context.Context, t *testing.T, client Client, env *Env) {
+	result, err := client.Screenshot(ctx, &screencraft.ScreenshotOptions{
+		URL:      env.TargetURL,
+		Format:   screencraft.FormatPNG,
+		FullPage: true,
+		Viewport: &screencraft.Viewport{Width: 1280, Height: 720},
+	})
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+
+	if !bytes.HasPrefix(result.Data, []byte("\x89PNG")) {
+		t.Errorf("Data is not a PNG image")
+	}
+	if result.Height != 0 && result.Height < 720 {
+		t.Errorf("Height = %d, want at least the viewport height 720", result.Height)
+	}
+
+	if env.Server != nil {
+		req := lastRequest(t, env.Server)
+		if req.Path != "/screenshots" {
+			t.Errorf("path = %q, want /screenshots", req.Path)
+		}
+		if full, _ := req.Body["fullPage"].(bool); !full {
+			t.Errorf("fullPage was not sent")
+		}
+	}
+}
+
+func clip(ctx context.Context, t *testing.T, client Client, env *Env) {
+	result, err := client.Screenshot(ctx, &screencraft.ScreenshotOptions{
+		URL:    env.TargetURL,
+		Format: screencraft.FormatPNG,
+		Clip:   &screencraft.Clip{X: 10, Y: 20, Width: 300, Height: 200},
+	})
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+
+	if result.Width != 0 && (result.Width != 300 || result.Height != 200) {
+		t.Errorf("size = %dx%d, want 300x200", result.Width, result.Height)
+	}
+
+	if env.Server != nil {
+		req := lastRequest(t, env.Server)
+		c, _ := req.Body["clip"].(map[string]interface{})
+		if c == nil || c["x"] != float64(10) || c["y"] != float64(20) {
+			t.Errorf("clip = %v, want x=10 y=20", req.Body["clip"])
+		}
+	}
+}
+
+func asyncWebhook(ctx context.Context, t *testing.T, client Client, env *Env) {
+	jobID, err := client.ScreenshotAsync(ctx, &screencraft.ScreenshotOptions{
+		URL:    env.TargetURL,
+		Format: screencraft.FormatPNG,
+		Webhook: &screencraft.WebhookConfig{
+			URL:    "https://hooks.example.com/screencraft",
+			Secret: "conformance",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ScreenshotAsync: %v", err)
+	}
+	if jobID == "" {
+		t.Errorf("job ID is empty")
+	}
+
+	if env.Server != nil {
+		req := lastRequest(t, env.Server)
+		hook, _ := req.Body["webhook"].(map[string]interface{})
+		if hook == nil || hook["url"] != "https://hooks.example.com/screencraft" {
+			t.Errorf("webhook = %v, want url to be sent", req.Body["webhook"])
+		}
+	}
+}
+
+func batch(ctx context.Context, t *testing.T, client Client, env *Env) {
+	widths := []int{375, 768, 1280, 1920}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(widths))
+	for i, width := range widths {
+		wg.Add(1)
+		go func(i, width int) {
+			defer wg.Done()
+			_, errs[i] = client.Screenshot(ctx, &screencraft.ScreenshotOptions{
+				URL:      env.TargetURL,
+				Format:   screencraft.FormatPNG,
+				Viewport: &screencraft.Viewport{Width: width, Height: 800},
+			})
+		}(i, width)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("capture %d (width %d): %v", i, widths[i], err)
+		}
+	}
+
+	if env.Server != nil {
+		if n := len(env.Server.Requests()); n != len(widths) {
+			t.Errorf("server received %d requests, want %d", n, len(widths))
+		}
+	}
+}
+
+func pdfHeaderFooter(ctx context.Context, t *testing.T, client Client, env *Env) {
+	header := `<div style="font-size:10px">Header</div>`
+	footer := `<div style="font-size:10px">Page <span class="pageNumber"></span></div>`
+
+	result, err := client.PDF(ctx, &screencraft.PDFOptions{
+		URL:                 env.TargetURL,
+		Format:              screencraft.A4,
+		DisplayHeaderFooter: true,
+		HeaderTemplate:      header,
+		FooterTemplate:      footer,
+		Margin:              &screencraft.PDFMargin{Top: "100px", Bottom: "100px"},
+	})
+	if err != nil {
+		t.Fatalf("PDF: %v", err)
+	}
+
+	if !bytes.HasPrefix(result.Data, []byte("%PDF-")) {
+		t.Errorf("Data is not a PDF document")
+	}
+
+	if env.Server != nil {
+		req := lastRequest(t, env.Server)
+		if req.Path != "/pdfs" {
+			t.Errorf("path = %q, want /pdfs", req.Path)
+		}
+		if req.Body["headerTemplate"] != header || req.Body["footerTemplate"] != footer {
+			t.Errorf("header/footer templates were not sent unchanged")
+		}
+		if show, _ := req.Body["displayHeaderFooter"].(bool); !show {
+			t.Errorf("displayHeaderFooter was not sent")
+		}
+	}
+}
+
+func retryOn429(ctx context.Context, t *testing.T, client Client, env *Env) {
+	const failures = 2
+	env.Server.FailNext(failures, 429)
+
+	if _, err := client.Screenshot(ctx, &screencraft.ScreenshotOptions{
+		URL:    env.TargetURL,
+		Format: screencraft.FormatPNG,
+	}); err != nil {
+		t.Fatalf("Screenshot after %d rate-limited attempts: %v", failures, err)
+	}
+
+	if n := len(env.Server.Requests()); n != failures+1 {
+		t.Errorf("server received %d requests, want %d", n, failures+1)
+	}
+}
+
+func lastRequest(t *testing.T, srv *screencrafttest.Server) screencrafttest.Request {
+	t.Helper()
+	reqs := srv.Requests()
+	if len(reqs) == 0 {
+		t.Fatalf("server received no requests")
+	}
+	return reqs[len(reqs)-1]
+}
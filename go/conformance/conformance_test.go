@@ -0,0 +1,21 @@
+package conformance_test
+
+import (
+	"testing"
+	"time"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/conformance"
+	"github.com/DancingTedDanson011/screencraft-go/screencrafttest"
+)
+
+func TestConformance(t *testing.T) {
+	srv := screencrafttest.NewServer()
+	defer srv.Close()
+
+	client := screencraft.New("test-key",
+		screencraft.WithBaseURL(srv.URL),
+		screencraft.WithRetryWait(time.Millisecond, 10*time.Millisecond),
+	)
+	conformance.Conformance(t, client, conformance.WithServer(srv))
+}
@@ -0,0 +1,148 @@
+package screencraft
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// credentialsRefreshMargin is how long before their expiry cached
+// credentials are refreshed.
+const credentialsRefreshMargin = time.Minute
+
+// CredentialsProvider supplies the API key for each request, e.g. from a
+// secrets manager or a short-lived token service, instead of a static key.
+//
+// If the provider also has an Invalidate(key string) method, it is called
+// when the API rejects key with 401, and the request is retried once with a
+// key fetched anew. CachedCredentials implements it.
+//
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	// APIKey returns the key to authenticate the next request with.
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider. It
+// also adapts token sources that refresh themselves, such as an
+// oauth2.TokenSource:
+//
+//	screencraft.CredentialsProviderFunc(func(ctx context.Context) (string, error) {
+//	    token, err := tokenSource.Token()
+//	    if err != nil {
+//	        return "", err
+//	    }
+//	    return token.AccessToken, nil
+//	})
+type CredentialsProviderFunc func(ctx context.Context) (string, error)
+
+// APIKey calls f(ctx).
+func (f CredentialsProviderFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// credentialsInvalidator is implemented by providers that cache keys.
+type credentialsInvalidator interface {
+	Invalidate(key string)
+}
+
+// WithCredentialsProvider authenticates requests with keys from provider
+// instead of the key passed to New. The provider is asked for a key before
+// every attempt, so keys it rotates are picked up without recreating the
+// client.
+//
+// Example:
+//
+//	client := screencraft.New("", screencraft.WithCredentialsProvider(
+//	    screencraft.NewCachedCredentials(func(ctx context.Context) (string, time.Time, error) {
+//	        secret, err := vault.Read(ctx, "secret/screencraft")
+//	        if err != nil {
+//	            return "", time.Time{}, err
+//	        }
+//	        return secret.Key, secret.Expiry, nil
+//	    }),
+//	))
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// CachedCredentials is a CredentialsProvider that caches the key returned by
+// a fetch function until shortly before it expires. Concurrent requests
+// share a single fetch.
+type CachedCredentials struct {
+	fetch func(ctx context.Context) (string, time.Time, error)
+
+	mu     sync.Mutex
+	key    string
+	expiry time.Time
+}
+
+// NewCachedCredentials creates a CachedCredentials that obtains keys from
+// fetch. fetch returns a key and when it expires; a zero expiry means the key
+// is valid until the API rejects it.
+func NewCachedCredentials(fetch func(ctx context.Context) (key string, expiry time.Time, err error)) *CachedCredentials {
+	return &CachedCredentials{fetch: fetch}
+}
+
+// APIKey returns the cached key, fetching a new one if there is none or it
+// is about to expire.
+func (c *CachedCredentials) APIKey(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != "" && (c.expiry.IsZero() || time.Now().Add(credentialsRefreshMargin).Before(c.expiry)) {
+		return c.key, nil
+	}
+
+	key, expiry, err := c.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.key, c.expiry = key, expiry
+	return key, nil
+}
+
+// Invalidate discards the cached key if it is key, so the next call to
+// APIKey fetches a new one.
+func (c *CachedCredentials) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key == key {
+		c.key, c.expiry = "", time.Time{}
+	}
+}
+
+// currentAPIKey returns the key to authenticate a request with, from the
+// client's credentials provider if it has one.
+func (c *Client) currentAPIKey(ctx context.Context) (string, error) {
+	if c.credentials == nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		return c.apiKey, nil
+	}
+
+	key, err := c.credentials.APIKey(ctx)
+	if err != nil {
+		return "", fmt.Errorf("screencraft: failed to get API key: %w", err)
+	}
+
+	c.mu.Lock()
+	c.credentialsKey = key
+	c.mu.Unlock()
+	return key, nil
+}
+
+// invalidateAPIKey tells the client's credentials provider that key was
+// rejected. It reports whether the provider can supply a new key.
+func (c *Client) invalidateAPIKey(key string) bool {
+	invalidator, ok := c.credentials.(credentialsInvalidator)
+	if !ok {
+		return false
+	}
+	invalidator.Invalidate(key)
+	return true
+}
@@ -0,0 +1,155 @@
+package screencraft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WithDeduplication coalesces concurrent identical synchronous captures:
+// while a Screenshot or PDF call is in flight, calls with the same options
+// (see OptionsFingerprint) and the same call options wait for it and share
+// its result instead of sending their own request. Each caller gets its own
+// copy of the result data.
+//
+// Each caller still honors its own context. The shared request is only
+// canceled once every caller waiting for it has given up. Captures delivered
+// by webhook or uploaded to storage are never coalesced.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithDeduplication(true))
+func WithDeduplication(enabled bool) Option {
+	return func(c *Client) {
+		if !enabled {
+			c.flights = nil
+			return
+		}
+		c.flights = &flightGroup{}
+	}
+}
+
+// flightKey returns the deduplication key for a capture, or "" if
+// deduplication is disabled.
+func (c *Client) flightKey(ctx context.Context, kind string, opts interface{}) string {
+	if c.flights == nil {
+		return ""
+	}
+	fingerprint := OptionsFingerprint(opts)
+	if fingerprint == "" {
+		return ""
+	}
+	// Calls whose options change how the request is sent run separately
+	return kind + ":" + fingerprint + ":" + callOptionsFrom(ctx).flightKey()
+}
+
+// flightKey identifies the call options that affect how a call is sent or
+// what it returns.
+func (o callOptions) flightKey() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d|%s|", o.priority, o.timeout)
+	if o.maxRetries != nil {
+		fmt.Fprintf(&b, "%d", *o.maxRetries)
+	}
+	fmt.Fprintf(&b, "|%q|%q|%t", o.ifNoneMatch, o.idempotencyKey, o.skipQuotaPacing)
+
+	names := make([]string, 0, len(o.headers))
+	for name := range o.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "|%q=%q", name, o.headers[name])
+	}
+	return b.String()
+}
+
+// sharedScreenshot returns a copy of a coalesced result that the caller can
+// modify without affecting the other waiters.
+func sharedScreenshot(r *ScreenshotResult) *ScreenshotResult {
+	result := *r
+	result.Data = bytes.Clone(r.Data)
+	result.Thumbnail = bytes.Clone(r.Thumbnail)
+	if r.Images != nil {
+		result.Images = make(map[Format][]byte, len(r.Images))
+		for format, data := range r.Images {
+			result.Images[format] = bytes.Clone(data)
+		}
+	}
+	return &result
+}
+
+// sharedPDF returns a copy of a coalesced result that the caller can modify
+// without affecting the other waiters.
+func sharedPDF(r *PDFResult) *PDFResult {
+	result := *r
+	result.Data = bytes.Clone(r.Data)
+	return &result
+}
+
+// flightGroup tracks in-flight calls by key.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is an in-flight call shared by one or more waiters.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	val     interface{}
+	err     error
+}
+
+// do calls fn once per key at a time, returning its result to every caller
+// with the same key. fn runs with a context that carries the values of the
+// first caller's ctx and is canceled once all waiters have returned early.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, ok := g.flights[key]
+	if ok {
+		f.waiters++
+	} else {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel, waiters: 1}
+		g.flights[key] = f
+
+		go func() {
+			f.val, f.err = fn(flightCtx)
+			cancel()
+
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody is left to receive the result; later callers start a
+			// new flight instead of joining a canceled one
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,240 @@
+package screencraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrResultChanged is returned when a download cannot be resumed because the
+// job result changed since the download started.
+var ErrResultChanged = errors.New("screencraft: job result changed during download")
+
+// DownloadJobResult downloads the result of a completed async job
+// (WebhookEvent.ResultURL) to w and returns the number of bytes written.
+//
+// If the connection breaks, the download resumes from the last received
+// byte with an HTTP Range request instead of starting over. Attempts that
+// make progress do not count against the client's retry limit, so large
+// results survive several interruptions.
+//
+// Credentials are only sent if resultURL is on the client's base URL, so
+// presigned storage URLs are fetched without them.
+//
+// Example:
+//
+//	f, err := os.Create("recording.mp4")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	if _, err := client.DownloadJobResult(ctx, event.ResultURL, f); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) DownloadJobResult(ctx context.Context, resultURL string, w io.Writer) (int64, error) {
+	return c.download(ctx, resultURL, w, 0, nil)
+}
+
+// DownloadJobResultFile downloads the result of a completed async job to the
+// file at path. If the file already holds the beginning of the result, e.g.
+// from a download interrupted by a restart, only the remaining bytes are
+// fetched. It returns the size of the file.
+func (c *Client) DownloadJobResultFile(ctx context.Context, resultURL, path string) (int64, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("screencraft: %w", err)
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("screencraft: %w", err)
+	}
+
+	restart := func() error {
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		_, err := f.Seek(0, io.SeekStart)
+		return err
+	}
+
+	n, err := c.download(ctx, resultURL, f, offset, restart)
+	if err != nil {
+		return n, err
+	}
+	if err := f.Sync(); err != nil {
+		return n, fmt.Errorf("screencraft: %w", err)
+	}
+	return n, nil
+}
+
+// download fetches resultURL into w, whose first offset bytes are already
+// written, resuming with Range requests after interruptions. restart, if
+// not nil, discards everything written to w when the download has to start
+// over.
+func (c *Client) download(ctx context.Context, resultURL string, w io.Writer, offset int64, restart func() error) (int64, error) {
+	if resultURL == "" {
+		return 0, NewValidationError("resultUrl", "result URL is required", "required").Error
+	}
+
+	authenticated := strings.HasPrefix(resultURL, c.BaseURL())
+
+	maxRetries, httpClient := c.callSettings(ctx)
+	policy := c.activeRetryPolicy()
+
+	written := offset
+	var validator string
+	var lastErr error
+	for failures := 0; failures <= maxRetries; {
+		if failures > 0 {
+			if err := c.sleep(ctx, c.clampRetryAfter(policy.Backoff(failures, lastErr), lastErr)); err != nil {
+				return written, err
+			}
+		}
+		if lastErr != nil {
+			c.logf("Resuming download of %s at byte %d", resultURL, written)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, resultURL, nil)
+		if err != nil {
+			return written, fmt.Errorf("screencraft: failed to create request: %w", err)
+		}
+		req.Header.Set("User-Agent", c.userAgent)
+		if authenticated {
+			apiKey, err := c.currentAPIKey(ctx)
+			if err != nil {
+				return written, err
+			}
+			c.setAuthHeader(req, apiKey)
+		}
+		if written > 0 {
+			req.Header.Set("Range", "bytes="+strconv.FormatInt(written, 10)+"-")
+			if validator != "" {
+				req.Header.Set("If-Range", validator)
+			}
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return written, ctx.Err()
+			}
+			lastErr = NewNetworkError(err)
+			failures++
+			continue
+		}
+
+		body, start, err := c.resumeBody(resp, written, validator, restart)
+		if err != nil {
+			resp.Body.Close()
+			if IsNetworkError(err) || IsRetryable(err) {
+				lastErr = err
+				failures++
+				continue
+			}
+			return written, err
+		}
+		if body == nil {
+			// The requested range starts at the end of the result
+			resp.Body.Close()
+			return written, nil
+		}
+		written = start
+		if validator == "" {
+			validator = resp.Header.Get("ETag")
+		}
+
+		n, err := io.Copy(w, body)
+		resp.Body.Close()
+		written += n
+		if err == nil {
+			return written, nil
+		}
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		// Interruptions after progress are resumed right away
+		lastErr = NewNetworkError(err)
+		if n > 0 {
+			failures = 0
+		} else {
+			failures++
+		}
+	}
+
+	return written, lastErr
+}
+
+// resumeBody checks a download response against the bytes already written
+// and returns the body to copy from and the offset it starts at, or a nil
+// body if nothing remains. On a full response to a resumed download it skips
+// the bytes already written if the result is unchanged, and restarts the
+// download otherwise.
+func (c *Client) resumeBody(resp *http.Response, written int64, validator string, restart func() error) (io.Reader, int64, error) {
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, err := contentRangeStart(resp.Header.Get("Content-Range"))
+		if err != nil || start != written {
+			return nil, 0, fmt.Errorf("screencraft: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		return resp.Body, written, nil
+
+	case http.StatusOK:
+		if written == 0 {
+			return resp.Body, 0, nil
+		}
+		if validator != "" && resp.Header.Get("ETag") == validator {
+			// The server ignored the range; skip what was already written
+			if _, err := io.CopyN(io.Discard, resp.Body, written); err != nil {
+				return nil, 0, NewNetworkError(err)
+			}
+			return resp.Body, written, nil
+		}
+		if restart == nil {
+			return nil, 0, ErrResultChanged
+		}
+		if err := restart(); err != nil {
+			return nil, 0, fmt.Errorf("screencraft: failed to restart download: %w", err)
+		}
+		return resp.Body, 0, nil
+
+	case http.StatusRequestedRangeNotSatisfiable:
+		if size, err := contentRangeSize(resp.Header.Get("Content-Range")); err == nil && size == written {
+			return nil, written, nil
+		}
+		return nil, 0, ErrResultChanged
+	}
+
+	return nil, 0, c.parseErrorResponse(resp)
+}
+
+// contentRangeStart returns the first byte position of a Content-Range
+// header such as "bytes 100-199/200".
+func contentRangeStart(header string) (int64, error) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	return strconv.ParseInt(start, 10, 64)
+}
+
+// contentRangeSize returns the complete length of a Content-Range header
+// such as "bytes */200".
+func contentRangeSize(header string) (int64, error) {
+	_, size, ok := strings.Cut(header, "/")
+	if !ok || size == "*" {
+		return 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	return strconv.ParseInt(size, 10, 64)
+}
@@ -0,0 +1,205 @@
+package screencraft
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AllFormats returns all supported screenshot formats.
+func AllFormats() []Format {
+	return []Format{FormatPNG, FormatJPEG, FormatWebP}
+}
+
+// AllPDFFormats returns all supported PDF paper formats.
+func AllPDFFormats() []PDFFormat {
+	return []PDFFormat{A4, A3, A5, Letter, Legal, Tabloid}
+}
+
+// AllPDFOrientations returns all supported PDF page orientations.
+func AllPDFOrientations() []PDFOrientation {
+	return []PDFOrientation{Portrait, Landscape}
+}
+
+// AllWaitUntil returns all supported page load events.
+func AllWaitUntil() []WaitUntil {
+	return []WaitUntil{WaitLoad, WaitDOMContentLoaded, WaitNetworkIdle, WaitNetworkIdle0}
+}
+
+// AllWaitModes returns all supported selector wait modes.
+func AllWaitModes() []WaitMode {
+	return []WaitMode{WaitModeAll, WaitModeAny}
+}
+
+// AllFits returns all supported resize fit modes.
+func AllFits() []Fit {
+	return []Fit{FitCover, FitContain, FitFill}
+}
+
+// AllResizeAlgorithms returns all supported resampling algorithms.
+func AllResizeAlgorithms() []ResizeAlgorithm {
+	return []ResizeAlgorithm{ResizeLanczos, ResizeBilinear, ResizeNearest}
+}
+
+// AllWatermarkPositions returns all supported watermark positions.
+func AllWatermarkPositions() []WatermarkPosition {
+	return []WatermarkPosition{
+		WatermarkCenter, WatermarkTopLeft, WatermarkTopRight,
+		WatermarkBottomLeft, WatermarkBottomRight, WatermarkTile,
+	}
+}
+
+// AllStorageProviders returns all supported storage providers.
+func AllStorageProviders() []StorageProvider {
+	return []StorageProvider{StorageS3}
+}
+
+// ParseFormat parses a screenshot format name, ignoring case.
+// "jpg" is accepted as an alias for FormatJPEG.
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "jpg" {
+		return FormatJPEG, nil
+	}
+	for _, f := range AllFormats() {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidFormat, s)
+}
+
+// ParsePDFFormat parses a PDF paper format name, ignoring case.
+func ParsePDFFormat(s string) (PDFFormat, error) {
+	name := strings.TrimSpace(s)
+	for _, f := range AllPDFFormats() {
+		if strings.EqualFold(string(f), name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidPDFFormat, s)
+}
+
+// ParsePDFOrientation parses a PDF page orientation, ignoring case.
+func ParsePDFOrientation(s string) (PDFOrientation, error) {
+	name := strings.TrimSpace(s)
+	for _, o := range AllPDFOrientations() {
+		if strings.EqualFold(string(o), name) {
+			return o, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidOrientation, s)
+}
+
+// ParseWaitUntil parses a page load event name, ignoring case.
+func ParseWaitUntil(s string) (WaitUntil, error) {
+	name := strings.TrimSpace(s)
+	for _, w := range AllWaitUntil() {
+		if strings.EqualFold(string(w), name) {
+			return w, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidWaitUntil, s)
+}
+
+// IsValid reports whether f is a supported screenshot format.
+func (f Format) IsValid() bool {
+	for _, v := range AllFormats() {
+		if f == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentType returns the MIME type of images in format f.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatPNG:
+		return "image/png"
+	case FormatJPEG:
+		return "image/jpeg"
+	case FormatWebP:
+		return "image/webp"
+	}
+	return ""
+}
+
+// IsValid reports whether f is a supported PDF paper format.
+func (f PDFFormat) IsValid() bool {
+	for _, v := range AllPDFFormats() {
+		if f == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether o is a supported PDF page orientation.
+func (o PDFOrientation) IsValid() bool {
+	for _, v := range AllPDFOrientations() {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether w is a supported page load event.
+func (w WaitUntil) IsValid() bool {
+	for _, v := range AllWaitUntil() {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether m is a supported selector wait mode.
+func (m WaitMode) IsValid() bool {
+	for _, v := range AllWaitModes() {
+		if m == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether f is a supported resize fit mode.
+func (f Fit) IsValid() bool {
+	for _, v := range AllFits() {
+		if f == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether a is a supported resampling algorithm.
+func (a ResizeAlgorithm) IsValid() bool {
+	for _, v := range AllResizeAlgorithms() {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether p is a supported watermark position.
+func (p WatermarkPosition) IsValid() bool {
+	for _, v := range AllWatermarkPositions() {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether p is a supported storage provider.
+func (p StorageProvider) IsValid() bool {
+	for _, v := range AllStorageProviders() {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
@@ -14,9 +14,21 @@ var (
 	// ErrMissingURL is returned when no URL is provided for capture.
 	ErrMissingURL = errors.New("screencraft: URL is required")
 
+	// ErrInvalidURL is returned when the target URL is malformed or not allowed.
+	ErrInvalidURL = errors.New("screencraft: invalid URL")
+
 	// ErrInvalidFormat is returned when an invalid format is specified.
 	ErrInvalidFormat = errors.New("screencraft: invalid format specified")
 
+	// ErrInvalidPDFFormat is returned when an invalid PDF paper format is specified.
+	ErrInvalidPDFFormat = errors.New("screencraft: invalid PDF format specified")
+
+	// ErrInvalidOrientation is returned when an invalid PDF orientation is specified.
+	ErrInvalidOrientation = errors.New("screencraft: invalid orientation specified")
+
+	// ErrInvalidWaitUntil is returned when an invalid page load event is specified.
+	ErrInvalidWaitUntil = errors.New("screencraft: invalid waitUntil event specified")
+
 	// ErrInvalidQuality is returned when quality is out of range.
 	ErrInvalidQuality = errors.New("screencraft: quality must be between 0 and 100")
 
@@ -49,6 +61,9 @@ type Error struct {
 
 	// Err is the underlying error, if any.
 	Err error
+
+	// RetryInfo describes the attempts made before the error was returned.
+	RetryInfo
 }
 
 // Error implements the error interface.
@@ -161,6 +176,12 @@ type TimeoutError struct {
 
 	// Duration is the timeout duration.
 	Duration time.Duration
+
+	// Phase is the stage of the request that timed out, if known.
+	Phase TimeoutPhase
+
+	// Timings contains the durations of the phases completed before the timeout.
+	Timings PhaseTimings
 }
 
 // NewTimeoutError creates a new TimeoutError.
@@ -175,6 +196,20 @@ func NewTimeoutError(duration time.Duration) *TimeoutError {
 	}
 }
 
+// newPhaseTimeoutError creates a TimeoutError for a timeout during phase.
+func newPhaseTimeoutError(phase TimeoutPhase, duration time.Duration, err error) *TimeoutError {
+	return &TimeoutError{
+		Error: &Error{
+			StatusCode: http.StatusGatewayTimeout,
+			Code:       "TIMEOUT",
+			Message:    fmt.Sprintf("operation timed out after %s during %s", duration.Round(time.Millisecond), phase),
+			Err:        err,
+		},
+		Duration: duration,
+		Phase:    phase,
+	}
+}
+
 // NetworkError represents a network-related error.
 type NetworkError struct {
 	*Error
@@ -195,6 +230,9 @@ func NewNetworkError(err error) *NetworkError {
 // ServerError represents a server-side error.
 type ServerError struct {
 	*Error
+
+	// RetryAfter is the wait requested by the server, if any.
+	RetryAfter time.Duration
 }
 
 // NewServerError creates a new ServerError.
@@ -256,6 +294,11 @@ func IsRetryable(err error) bool {
 		return true
 	}
 
+	var serverErr *ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.IsRetryable()
+	}
+
 	var netErr *NetworkError
 	if errors.As(err, &netErr) {
 		return true
@@ -275,5 +318,42 @@ func GetRetryAfter(err error) time.Duration {
 	if errors.As(err, &rateErr) {
 		return rateErr.RetryAfter
 	}
+
+	var serverErr *ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.RetryAfter
+	}
 	return 0
 }
+
+// asError returns the *Error in err's chain, including one embedded in the
+// specific error types, or nil. errors.As does not find embedded errors,
+// since their promoted Unwrap returns the cause instead.
+func asError(err error) *Error {
+	var (
+		authErr       *AuthenticationError
+		rateErr       *RateLimitError
+		validationErr *ValidationError
+		timeoutErr    *TimeoutError
+		networkErr    *NetworkError
+		serverErr     *ServerError
+		scErr         *Error
+	)
+	switch {
+	case errors.As(err, &authErr):
+		return authErr.Error
+	case errors.As(err, &rateErr):
+		return rateErr.Error
+	case errors.As(err, &validationErr):
+		return validationErr.Error
+	case errors.As(err, &timeoutErr):
+		return timeoutErr.Error
+	case errors.As(err, &networkErr):
+		return networkErr.Error
+	case errors.As(err, &serverErr):
+		return serverErr.Error
+	case errors.As(err, &scErr):
+		return scErr
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package screencraft
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// failoverThreshold is the number of consecutive 5xx responses after which
+	// a base URL is considered unhealthy. Network errors count immediately.
+	failoverThreshold = 3
+
+	// failoverCooldown is how long an unhealthy base URL is skipped before
+	// the client tries it again.
+	failoverCooldown = 30 * time.Second
+)
+
+// WithBaseURLs sets a primary API base URL and fallbacks, e.g. in other
+// regions. Requests go to the first healthy URL in order. A URL becomes
+// unhealthy after a network error or several consecutive 5xx responses and
+// is skipped for a cooldown period, after which the client fails back to it.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithBaseURLs(
+//	    "https://eu.screencraftapi.com/api/v1",
+//	    "https://us.screencraftapi.com/api/v1",
+//	))
+func WithBaseURLs(primary string, fallbacks ...string) Option {
+	return func(c *Client) {
+		c.baseURL = primary
+		c.baseURLs = nil
+		if len(fallbacks) > 0 {
+			c.baseURLs = newBaseURLPool(append([]string{primary}, fallbacks...))
+		}
+	}
+}
+
+// BaseURL returns the base URL the client currently sends requests to.
+func (c *Client) BaseURL() string {
+	base, _ := c.selectBaseURL()
+	return base
+}
+
+// baseURLPool tracks the health of a list of base URLs.
+type baseURLPool struct {
+	mu        sync.Mutex
+	urls      []string
+	failures  []int
+	downUntil []time.Time
+}
+
+// newBaseURLPool creates a pool with all URLs healthy.
+func newBaseURLPool(urls []string) *baseURLPool {
+	return &baseURLPool{
+		urls:      urls,
+		failures:  make([]int, len(urls)),
+		downUntil: make([]time.Time, len(urls)),
+	}
+}
+
+// pick returns the first healthy URL and its index. If all URLs are
+// unhealthy, it returns the one that recovers first.
+func (p *baseURLPool) pick(now time.Time) (string, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best := 0
+	for i := range p.urls {
+		if !now.Before(p.downUntil[i]) {
+			return p.urls[i], i
+		}
+		if p.downUntil[i].Before(p.downUntil[best]) {
+			best = i
+		}
+	}
+	return p.urls[best], best
+}
+
+// report records the outcome of a request to the URL at index i.
+func (p *baseURLPool) report(i int, failed, fatal bool, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !failed {
+		p.failures[i] = 0
+		p.downUntil[i] = time.Time{}
+		return
+	}
+
+	p.failures[i]++
+	if fatal || p.failures[i] >= failoverThreshold {
+		p.downUntil[i] = now.Add(failoverCooldown)
+		p.failures[i] = 0
+	}
+}
+
+// selectBaseURL returns the base URL for the next attempt and its index in
+// the failover pool, or -1 without failover.
+func (c *Client) selectBaseURL() (string, int) {
+	if c.baseURLs == nil {
+		return c.baseURL, -1
+	}
+	return c.baseURLs.pick(c.now())
+}
+
+// reportBaseURL records the outcome of an attempt against the base URL at
+// index i. Network errors mark the URL unhealthy at once; server errors do so
+// once they persist.
+func (c *Client) reportBaseURL(i int, networkErr bool, statusCode int) {
+	if c.baseURLs == nil || i < 0 {
+		return
+	}
+
+	failed := networkErr || statusCode >= 500
+	c.baseURLs.report(i, failed, networkErr, c.now())
+	if failed {
+		c.logf("Request to %s failed, tracking for failover", c.baseURLs.urls[i])
+	}
+}
@@ -0,0 +1,95 @@
+package screencraft
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// volatileFields are top-level option fields that do not affect the captured
+// output and are therefore excluded from fingerprints.
+var volatileFields = []string{"webhook", "clientReference", "storage", "priority"}
+
+// unorderedFields are top-level option fields whose element order does not
+// affect the captured output.
+var unorderedFields = []string{"cookies", "headers"}
+
+// OptionsFingerprint returns a canonical hash of capture options.
+//
+// Two option values that produce the same capture yield the same fingerprint,
+// regardless of map or cookie/header ordering. Volatile fields such as the
+// webhook configuration (including its secret) are excluded. The fingerprint
+// is suitable for use as a cache or deduplication key.
+//
+// opts is typically a *ScreenshotOptions or *PDFOptions, but any value that
+// marshals to a JSON object is accepted. An empty string is returned if opts
+// cannot be marshaled.
+//
+// Example:
+//
+//	key := screencraft.OptionsFingerprint(&screencraft.ScreenshotOptions{
+//	    URL:    "https://example.com",
+//	    Format: screencraft.FormatPNG,
+//	})
+func OptionsFingerprint(opts interface{}) string {
+	raw, err := json.Marshal(opts)
+	if err != nil {
+		return ""
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		// Not a JSON object, hash the value as-is
+		sum := sha256.Sum256(raw)
+		return hex.EncodeToString(sum[:])
+	}
+
+	for _, name := range volatileFields {
+		delete(fields, name)
+	}
+
+	if u, ok := fields["url"].(string); ok {
+		fields["url"] = normalizedURL(u)
+	}
+
+	for _, name := range unorderedFields {
+		if list, ok := fields[name].([]interface{}); ok {
+			fields[name] = sortedByJSON(list)
+		}
+	}
+
+	// encoding/json emits map keys in sorted order, which makes the
+	// marshaled form canonical.
+	canonical, err := json.Marshal(fields)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// sortedByJSON returns a copy of list sorted by each element's JSON encoding.
+func sortedByJSON(list []interface{}) []interface{} {
+	type entry struct {
+		key   string
+		value interface{}
+	}
+
+	entries := make([]entry, len(list))
+	for i, v := range list {
+		b, _ := json.Marshal(v)
+		entries[i] = entry{key: string(b), value: v}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	sorted := make([]interface{}, len(entries))
+	for i, e := range entries {
+		sorted[i] = e.value
+	}
+	return sorted
+}
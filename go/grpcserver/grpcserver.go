@@ -0,0 +1,301 @@
+// Package grpcserver serves the ScreenCraft SDK over gRPC, so services
+// written in other languages can capture screenshots and PDFs through a
+// shared client that holds the API key, retries, caching and rate limiting.
+//
+// The service is defined in screencraft.proto; generated Go stubs are in the
+// screencraftpb package. Capture options travel as JSON in the encoding of
+// screencraft.ScreenshotOptions and screencraft.PDFOptions.
+//
+// Basic usage:
+//
+//	client := screencraft.New(apiKey)
+//
+//	lis, err := net.Listen("tcp", ":9090")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	s := grpc.NewServer()
+//	screencraftpb.RegisterScreenCraftServer(s, grpcserver.New(client))
+//	log.Fatal(s.Serve(lis))
+//
+// The package is a separate module so that only programs serving gRPC
+// depend on google.golang.org/grpc.
+package grpcserver
+
+//go:generate protoc -I .. --go_out=.. --go_opt=module=github.com/DancingTedDanson011/screencraft-go --go-grpc_out=.. --go-grpc_opt=module=github.com/DancingTedDanson011/screencraft-go grpcserver/screencraft.proto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/grpcserver/screencraftpb"
+)
+
+// Server implements screencraftpb.ScreenCraftServer on top of a
+// screencraft.Service.
+type Server struct {
+	screencraftpb.UnimplementedScreenCraftServer
+
+	service screencraft.Service
+	jobs    screencraft.JobStore
+}
+
+var _ screencraftpb.ScreenCraftServer = (*Server)(nil)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithJobStore serves GetJob and ListPendingJobs from store. Use the store
+// the client records its jobs in (see screencraft.WithJobStore). Without a
+// store, both fail with FailedPrecondition.
+func WithJobStore(store screencraft.JobStore) Option {
+	return func(s *Server) {
+		s.jobs = store
+	}
+}
+
+// New creates a Server that handles requests with service, typically a
+// *screencraft.Client.
+func New(service screencraft.Service, opts ...Option) *Server {
+	s := &Server{service: service}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Screenshot captures a screenshot synchronously.
+func (s *Server) Screenshot(ctx context.Context, req *screencraftpb.ScreenshotRequest) (*screencraftpb.CaptureResponse, error) {
+	var opts screencraft.ScreenshotOptions
+	if err := decodeOptions(req.GetOptionsJson(), &opts); err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.Screenshot(ctx, &opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &screencraftpb.CaptureResponse{
+		Data:        result.Data,
+		ContentType: result.ContentType,
+		Width:       int32(result.Width),
+		Height:      int32(result.Height),
+		RequestId:   result.RequestID,
+		OptionsHash: result.OptionsHash,
+		CapturedAt:  timestamp(result.CapturedAt),
+	}, nil
+}
+
+// PDF generates a PDF synchronously.
+func (s *Server) PDF(ctx context.Context, req *screencraftpb.PDFRequest) (*screencraftpb.CaptureResponse, error) {
+	var opts screencraft.PDFOptions
+	if err := decodeOptions(req.GetOptionsJson(), &opts); err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.PDF(ctx, &opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &screencraftpb.CaptureResponse{
+		Data:        result.Data,
+		ContentType: result.ContentType,
+		Pages:       int32(result.Pages),
+		RequestId:   result.RequestID,
+		OptionsHash: result.OptionsHash,
+		CapturedAt:  timestamp(result.CapturedAt),
+	}, nil
+}
+
+// SubmitScreenshot starts an asynchronous screenshot.
+func (s *Server) SubmitScreenshot(ctx context.Context, req *screencraftpb.ScreenshotRequest) (*screencraftpb.Job, error) {
+	var opts screencraft.ScreenshotOptions
+	if err := decodeOptions(req.GetOptionsJson(), &opts); err != nil {
+		return nil, err
+	}
+
+	jobID, err := s.service.ScreenshotAsync(ctx, &opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return submittedJob(jobID, "screenshot", &opts), nil
+}
+
+// SubmitPDF starts an asynchronous PDF.
+func (s *Server) SubmitPDF(ctx context.Context, req *screencraftpb.PDFRequest) (*screencraftpb.Job, error) {
+	var opts screencraft.PDFOptions
+	if err := decodeOptions(req.GetOptionsJson(), &opts); err != nil {
+		return nil, err
+	}
+
+	jobID, err := s.service.PDFAsync(ctx, &opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return submittedJob(jobID, "pdf", &opts), nil
+}
+
+// GetJob returns the tracked state of a job.
+func (s *Server) GetJob(ctx context.Context, req *screencraftpb.GetJobRequest) (*screencraftpb.Job, error) {
+	if req.GetJobId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+	if s.jobs == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no job store configured")
+	}
+
+	job, err := s.jobs.Get(req.GetJobId())
+	if errors.Is(err, screencraft.ErrJobNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return jobMessage(job), nil
+}
+
+// WatchJob streams the progress events of a job until it finishes.
+func (s *Server) WatchJob(req *screencraftpb.WatchJobRequest, stream screencraftpb.ScreenCraft_WatchJobServer) error {
+	if req.GetJobId() == "" {
+		return status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	events, err := s.service.StreamJobEvents(stream.Context(), req.GetJobId())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for event := range events {
+		if event.Err != nil {
+			return toStatus(event.Err)
+		}
+
+		msg := &screencraftpb.JobEvent{
+			Id:        event.ID,
+			Type:      string(event.Type),
+			JobId:     event.JobID,
+			Progress:  event.Progress,
+			Message:   event.Message,
+			ResultUrl: event.ResultURL,
+			Time:      timestamp(event.Time),
+		}
+		if event.Error != nil {
+			msg.Error = event.Error.Message
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPendingJobs returns the tracked jobs still in flight.
+func (s *Server) ListPendingJobs(ctx context.Context, req *screencraftpb.ListPendingJobsRequest) (*screencraftpb.ListPendingJobsResponse, error) {
+	if s.jobs == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no job store configured")
+	}
+
+	pending, err := s.jobs.Pending()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &screencraftpb.ListPendingJobsResponse{}
+	for _, job := range pending {
+		resp.Jobs = append(resp.Jobs, jobMessage(job))
+	}
+	return resp, nil
+}
+
+// decodeOptions decodes JSON-encoded capture options.
+func decodeOptions(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return status.Error(codes.InvalidArgument, "options_json is required")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid options_json: %v", err)
+	}
+	return nil
+}
+
+// submittedJob describes a job the API has just accepted.
+func submittedJob(jobID, kind string, opts interface{}) *screencraftpb.Job {
+	return &screencraftpb.Job{
+		JobId:       jobID,
+		Status:      string(screencraft.JobPending),
+		Kind:        kind,
+		OptionsHash: screencraft.OptionsFingerprint(opts),
+		SubmittedAt: timestamppb.Now(),
+	}
+}
+
+// jobMessage converts a job record.
+func jobMessage(job *screencraft.JobRecord) *screencraftpb.Job {
+	return &screencraftpb.Job{
+		JobId:       job.JobID,
+		Status:      string(job.Status),
+		Kind:        job.Kind,
+		OptionsHash: job.OptionsHash,
+		Error:       job.Error,
+		SubmittedAt: timestamp(job.SubmittedAt),
+	}
+}
+
+// timestamp converts t, leaving zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// invalidOptionErrors are the SDK's client-side validation errors.
+var invalidOptionErrors = []error{
+	screencraft.ErrMissingURL,
+	screencraft.ErrInvalidURL,
+	screencraft.ErrInvalidFormat,
+	screencraft.ErrInvalidPDFFormat,
+	screencraft.ErrInvalidOrientation,
+	screencraft.ErrInvalidWaitUntil,
+	screencraft.ErrInvalidQuality,
+	screencraft.ErrInvalidViewport,
+}
+
+// toStatus maps an SDK error to a gRPC status.
+func toStatus(err error) error {
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, screencraft.ErrContextCanceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded), screencraft.IsTimeoutError(err):
+		code = codes.DeadlineExceeded
+	case screencraft.IsValidationError(err), isInvalidOption(err):
+		code = codes.InvalidArgument
+	case screencraft.IsAuthenticationError(err):
+		code = codes.Unauthenticated
+	case screencraft.IsRateLimitError(err):
+		code = codes.ResourceExhausted
+	case screencraft.IsNetworkError(err), screencraft.IsServerError(err):
+		code = codes.Unavailable
+	}
+	return status.Error(code, err.Error())
+}
+
+// isInvalidOption reports whether err is a client-side validation error.
+func isInvalidOption(err error) bool {
+	for _, target := range invalidOptionErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
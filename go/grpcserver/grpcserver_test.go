@@ -0,0 +1,236 @@
+package grpcserver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/grpcserver"
+	"github.com/DancingTedDanson011/screencraft-go/grpcserver/screencraftpb"
+	"github.com/DancingTedDanson011/screencraft-go/jobstore"
+	"github.com/DancingTedDanson011/screencraft-go/screencrafttest"
+)
+
+// dial serves srv over an in-memory connection and returns a client for it.
+func dial(t *testing.T, srv *grpcserver.Server) screencraftpb.ScreenCraftClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	screencraftpb.RegisterScreenCraftServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return screencraftpb.NewScreenCraftClient(conn)
+}
+
+func newClient(t *testing.T, opts ...screencraft.Option) (*screencraft.Client, *screencrafttest.Server) {
+	t.Helper()
+
+	api := screencrafttest.NewServer()
+	t.Cleanup(api.Close)
+	opts = append([]screencraft.Option{
+		screencraft.WithBaseURL(api.URL),
+		screencraft.WithMaxRetries(0),
+	}, opts...)
+	return screencraft.New("test-key", opts...), api
+}
+
+func TestScreenshot(t *testing.T) {
+	client, _ := newClient(t)
+	sc := dial(t, grpcserver.New(client))
+
+	resp, err := sc.Screenshot(context.Background(), &screencraftpb.ScreenshotRequest{
+		OptionsJson: []byte(`{"url":"https://example.com","viewport":{"width":800,"height":600}}`),
+	})
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+	if !bytes.HasPrefix(resp.Data, []byte("\x89PNG")) {
+		t.Errorf("Data is not a PNG image")
+	}
+	if resp.Width != 800 || resp.Height != 600 {
+		t.Errorf("size = %dx%d, want 800x600", resp.Width, resp.Height)
+	}
+	if resp.ContentType != "image/png" {
+		t.Errorf("ContentType = %q, want image/png", resp.ContentType)
+	}
+	if resp.RequestId == "" {
+		t.Errorf("RequestId is empty")
+	}
+}
+
+func TestPDF(t *testing.T) {
+	client, _ := newClient(t)
+	sc := dial(t, grpcserver.New(client))
+
+	resp, err := sc.PDF(context.Background(), &screencraftpb.PDFRequest{
+		OptionsJson: []byte(`{"url":"https://example.com","format":"A4"}`),
+	})
+	if err != nil {
+		t.Fatalf("PDF: %v", err)
+	}
+	if !bytes.HasPrefix(resp.Data, []byte("%PDF-")) {
+		t.Errorf("Data is not a PDF document")
+	}
+	if resp.Pages != 1 {
+		t.Errorf("Pages = %d, want 1", resp.Pages)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		fail    int
+		want    codes.Code
+	}{
+		{name: "missing options", options: "", want: codes.InvalidArgument},
+		{name: "malformed options", options: `{"url":`, want: codes.InvalidArgument},
+		{name: "invalid options", options: `{"url":"not a url"}`, want: codes.InvalidArgument},
+		{name: "rate limited", options: `{"url":"https://example.com"}`, fail: http.StatusTooManyRequests, want: codes.ResourceExhausted},
+		{name: "unauthorized", options: `{"url":"https://example.com"}`, fail: http.StatusUnauthorized, want: codes.Unauthenticated},
+		{name: "server error", options: `{"url":"https://example.com"}`, fail: http.StatusBadGateway, want: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, api := newClient(t)
+			sc := dial(t, grpcserver.New(client))
+			if tt.fail != 0 {
+				api.FailNext(1, tt.fail)
+			}
+
+			_, err := sc.Screenshot(context.Background(), &screencraftpb.ScreenshotRequest{
+				OptionsJson: []byte(tt.options),
+			})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %s, want %s (err: %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestJobs(t *testing.T) {
+	store, err := jobstore.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := newClient(t, screencraft.WithJobStore(store))
+	sc := dial(t, grpcserver.New(client, grpcserver.WithJobStore(store)))
+	ctx := context.Background()
+
+	job, err := sc.SubmitScreenshot(ctx, &screencraftpb.ScreenshotRequest{
+		OptionsJson: []byte(`{"url":"https://example.com","webhook":{"url":"https://hooks.example.com"}}`),
+	})
+	if err != nil {
+		t.Fatalf("SubmitScreenshot: %v", err)
+	}
+	if job.JobId == "" || job.Kind != "screenshot" || job.Status != "pending" {
+		t.Errorf("job = %v, want a pending screenshot job", job)
+	}
+
+	got, err := sc.GetJob(ctx, &screencraftpb.GetJobRequest{JobId: job.JobId})
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if got.JobId != job.JobId || got.OptionsHash != job.OptionsHash {
+		t.Errorf("GetJob = %v, want %v", got, job)
+	}
+
+	pending, err := sc.ListPendingJobs(ctx, &screencraftpb.ListPendingJobsRequest{})
+	if err != nil {
+		t.Fatalf("ListPendingJobs: %v", err)
+	}
+	if len(pending.Jobs) != 1 || pending.Jobs[0].JobId != job.JobId {
+		t.Errorf("pending jobs = %v, want only %s", pending.Jobs, job.JobId)
+	}
+
+	if _, err := sc.GetJob(ctx, &screencraftpb.GetJobRequest{JobId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetJob(unknown) code = %s, want NotFound", status.Code(err))
+	}
+}
+
+func TestJobsWithoutStore(t *testing.T) {
+	client, _ := newClient(t)
+	sc := dial(t, grpcserver.New(client))
+
+	_, err := sc.ListPendingJobs(context.Background(), &screencraftpb.ListPendingJobsRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("code = %s, want FailedPrecondition", status.Code(err))
+	}
+}
+
+// eventService streams fixed job events.
+type eventService struct {
+	screencraft.Service
+	events []screencraft.JobEvent
+}
+
+func (s *eventService) StreamJobEvents(ctx context.Context, jobID string) (<-chan screencraft.JobEvent, error) {
+	ch := make(chan screencraft.JobEvent, len(s.events))
+	for _, e := range s.events {
+		e.JobID = jobID
+		ch <- e
+	}
+	close(ch)
+	return ch, nil
+}
+
+func TestWatchJob(t *testing.T) {
+	now := time.Now()
+	svc := &eventService{events: []screencraft.JobEvent{
+		{ID: "1", Type: screencraft.JobEventQueued, Time: now},
+		{ID: "2", Type: screencraft.JobEventCapturing, Progress: 0.5, Time: now},
+		{ID: "3", Type: screencraft.JobEventDone, ResultURL: "https://cdn.example.com/r.png", Time: now},
+	}}
+	sc := dial(t, grpcserver.New(svc))
+
+	stream, err := sc.WatchJob(context.Background(), &screencraftpb.WatchJobRequest{JobId: "job_1"})
+	if err != nil {
+		t.Fatalf("WatchJob: %v", err)
+	}
+
+	var got []*screencraftpb.JobEvent
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got = append(got, event)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("received %d events, want 3", len(got))
+	}
+	if got[1].Progress != 0.5 || got[1].JobId != "job_1" {
+		t.Errorf("event 2 = %v, want progress 0.5 for job_1", got[1])
+	}
+	if got[2].Type != "done" || got[2].ResultUrl != "https://cdn.example.com/r.png" {
+		t.Errorf("last event = %v, want done with the result URL", got[2])
+	}
+}
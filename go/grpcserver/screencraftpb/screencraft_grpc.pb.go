@@ -0,0 +1,392 @@
+// Service definition for exposing a screencraft.Client over gRPC.
+//
+// Capture options are passed as JSON in the encoding of the REST API and of
+// the Go ScreenshotOptions and PDFOptions types, so the service does not
+// have to track every option field by field.
+//
+// The Go server is the grpcserver package; the Go stubs in screencraftpb are
+// generated with
+//
+//	protoc --go_out=. --go_opt=module=github.com/DancingTedDanson011/screencraft-go \
+//	    --go-grpc_out=. --go-grpc_opt=module=github.com/DancingTedDanson011/screencraft-go \
+//	    grpcserver/screencraft.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: grpcserver/screencraft.proto
+
+package screencraftpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScreenCraft_Screenshot_FullMethodName       = "/screencraft.v1.ScreenCraft/Screenshot"
+	ScreenCraft_PDF_FullMethodName              = "/screencraft.v1.ScreenCraft/PDF"
+	ScreenCraft_SubmitScreenshot_FullMethodName = "/screencraft.v1.ScreenCraft/SubmitScreenshot"
+	ScreenCraft_SubmitPDF_FullMethodName        = "/screencraft.v1.ScreenCraft/SubmitPDF"
+	ScreenCraft_GetJob_FullMethodName           = "/screencraft.v1.ScreenCraft/GetJob"
+	ScreenCraft_WatchJob_FullMethodName         = "/screencraft.v1.ScreenCraft/WatchJob"
+	ScreenCraft_ListPendingJobs_FullMethodName  = "/screencraft.v1.ScreenCraft/ListPendingJobs"
+)
+
+// ScreenCraftClient is the client API for ScreenCraft service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScreenCraft captures screenshots and PDFs.
+type ScreenCraftClient interface {
+	// Screenshot captures a screenshot synchronously. See Client.Screenshot.
+	Screenshot(ctx context.Context, in *ScreenshotRequest, opts ...grpc.CallOption) (*CaptureResponse, error)
+	// PDF generates a PDF synchronously. See Client.PDF.
+	PDF(ctx context.Context, in *PDFRequest, opts ...grpc.CallOption) (*CaptureResponse, error)
+	// SubmitScreenshot starts an asynchronous screenshot. The options must
+	// include a webhook. See Client.ScreenshotAsync.
+	SubmitScreenshot(ctx context.Context, in *ScreenshotRequest, opts ...grpc.CallOption) (*Job, error)
+	// SubmitPDF starts an asynchronous PDF. The options must include a
+	// webhook. See Client.PDFAsync.
+	SubmitPDF(ctx context.Context, in *PDFRequest, opts ...grpc.CallOption) (*Job, error)
+	// GetJob returns the tracked state of a job. See JobStore.Get.
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// WatchJob streams the progress events of a job until it finishes. See
+	// Client.StreamJobEvents.
+	WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobEvent], error)
+	// ListPendingJobs returns the tracked jobs still in flight. See
+	// Client.ResumePendingJobs.
+	ListPendingJobs(ctx context.Context, in *ListPendingJobsRequest, opts ...grpc.CallOption) (*ListPendingJobsResponse, error)
+}
+
+type screenCraftClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScreenCraftClient(cc grpc.ClientConnInterface) ScreenCraftClient {
+	return &screenCraftClient{cc}
+}
+
+func (c *screenCraftClient) Screenshot(ctx context.Context, in *ScreenshotRequest, opts ...grpc.CallOption) (*CaptureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureResponse)
+	err := c.cc.Invoke(ctx, ScreenCraft_Screenshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *screenCraftClient) PDF(ctx context.Context, in *PDFRequest, opts ...grpc.CallOption) (*CaptureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureResponse)
+	err := c.cc.Invoke(ctx, ScreenCraft_PDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *screenCraftClient) SubmitScreenshot(ctx context.Context, in *ScreenshotRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, ScreenCraft_SubmitScreenshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *screenCraftClient) SubmitPDF(ctx context.Context, in *PDFRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, ScreenCraft_SubmitPDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *screenCraftClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, ScreenCraft_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *screenCraftClient) WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScreenCraft_ServiceDesc.Streams[0], ScreenCraft_WatchJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchJobRequest, JobEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScreenCraft_WatchJobClient = grpc.ServerStreamingClient[JobEvent]
+
+func (c *screenCraftClient) ListPendingJobs(ctx context.Context, in *ListPendingJobsRequest, opts ...grpc.CallOption) (*ListPendingJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingJobsResponse)
+	err := c.cc.Invoke(ctx, ScreenCraft_ListPendingJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScreenCraftServer is the server API for ScreenCraft service.
+// All implementations must embed UnimplementedScreenCraftServer
+// for forward compatibility.
+//
+// ScreenCraft captures screenshots and PDFs.
+type ScreenCraftServer interface {
+	// Screenshot captures a screenshot synchronously. See Client.Screenshot.
+	Screenshot(context.Context, *ScreenshotRequest) (*CaptureResponse, error)
+	// PDF generates a PDF synchronously. See Client.PDF.
+	PDF(context.Context, *PDFRequest) (*CaptureResponse, error)
+	// SubmitScreenshot starts an asynchronous screenshot. The options must
+	// include a webhook. See Client.ScreenshotAsync.
+	SubmitScreenshot(context.Context, *ScreenshotRequest) (*Job, error)
+	// SubmitPDF starts an asynchronous PDF. The options must include a
+	// webhook. See Client.PDFAsync.
+	SubmitPDF(context.Context, *PDFRequest) (*Job, error)
+	// GetJob returns the tracked state of a job. See JobStore.Get.
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	// WatchJob streams the progress events of a job until it finishes. See
+	// Client.StreamJobEvents.
+	WatchJob(*WatchJobRequest, grpc.ServerStreamingServer[JobEvent]) error
+	// ListPendingJobs returns the tracked jobs still in flight. See
+	// Client.ResumePendingJobs.
+	ListPendingJobs(context.Context, *ListPendingJobsRequest) (*ListPendingJobsResponse, error)
+	mustEmbedUnimplementedScreenCraftServer()
+}
+
+// UnimplementedScreenCraftServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScreenCraftServer struct{}
+
+func (UnimplementedScreenCraftServer) Screenshot(context.Context, *ScreenshotRequest) (*CaptureResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Screenshot not implemented")
+}
+func (UnimplementedScreenCraftServer) PDF(context.Context, *PDFRequest) (*CaptureResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PDF not implemented")
+}
+func (UnimplementedScreenCraftServer) SubmitScreenshot(context.Context, *ScreenshotRequest) (*Job, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitScreenshot not implemented")
+}
+func (UnimplementedScreenCraftServer) SubmitPDF(context.Context, *PDFRequest) (*Job, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitPDF not implemented")
+}
+func (UnimplementedScreenCraftServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedScreenCraftServer) WatchJob(*WatchJobRequest, grpc.ServerStreamingServer[JobEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchJob not implemented")
+}
+func (UnimplementedScreenCraftServer) ListPendingJobs(context.Context, *ListPendingJobsRequest) (*ListPendingJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPendingJobs not implemented")
+}
+func (UnimplementedScreenCraftServer) mustEmbedUnimplementedScreenCraftServer() {}
+func (UnimplementedScreenCraftServer) testEmbeddedByValue()                     {}
+
+// UnsafeScreenCraftServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScreenCraftServer will
+// result in compilation errors.
+type UnsafeScreenCraftServer interface {
+	mustEmbedUnimplementedScreenCraftServer()
+}
+
+func RegisterScreenCraftServer(s grpc.ServiceRegistrar, srv ScreenCraftServer) {
+	// If the following call panics, it indicates UnimplementedScreenCraftServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScreenCraft_ServiceDesc, srv)
+}
+
+func _ScreenCraft_Screenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScreenshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScreenCraftServer).Screenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScreenCraft_Screenshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScreenCraftServer).Screenshot(ctx, req.(*ScreenshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScreenCraft_PDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScreenCraftServer).PDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScreenCraft_PDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScreenCraftServer).PDF(ctx, req.(*PDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScreenCraft_SubmitScreenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScreenshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScreenCraftServer).SubmitScreenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScreenCraft_SubmitScreenshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScreenCraftServer).SubmitScreenshot(ctx, req.(*ScreenshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScreenCraft_SubmitPDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScreenCraftServer).SubmitPDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScreenCraft_SubmitPDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScreenCraftServer).SubmitPDF(ctx, req.(*PDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScreenCraft_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScreenCraftServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScreenCraft_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScreenCraftServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScreenCraft_WatchJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScreenCraftServer).WatchJob(m, &grpc.GenericServerStream[WatchJobRequest, JobEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScreenCraft_WatchJobServer = grpc.ServerStreamingServer[JobEvent]
+
+func _ScreenCraft_ListPendingJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScreenCraftServer).ListPendingJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScreenCraft_ListPendingJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScreenCraftServer).ListPendingJobs(ctx, req.(*ListPendingJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScreenCraft_ServiceDesc is the grpc.ServiceDesc for ScreenCraft service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScreenCraft_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "screencraft.v1.ScreenCraft",
+	HandlerType: (*ScreenCraftServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Screenshot",
+			Handler:    _ScreenCraft_Screenshot_Handler,
+		},
+		{
+			MethodName: "PDF",
+			Handler:    _ScreenCraft_PDF_Handler,
+		},
+		{
+			MethodName: "SubmitScreenshot",
+			Handler:    _ScreenCraft_SubmitScreenshot_Handler,
+		},
+		{
+			MethodName: "SubmitPDF",
+			Handler:    _ScreenCraft_SubmitPDF_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _ScreenCraft_GetJob_Handler,
+		},
+		{
+			MethodName: "ListPendingJobs",
+			Handler:    _ScreenCraft_ListPendingJobs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJob",
+			Handler:       _ScreenCraft_WatchJob_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpcserver/screencraft.proto",
+}
@@ -0,0 +1,299 @@
+package screencraft
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HandlerOptions configures the handler returned by NewHandler.
+type HandlerOptions struct {
+	// Allowlist restricts the hosts that can be captured. An entry matches a
+	// host exactly, or any of its subdomains with a leading "*.", e.g.
+	// "*.example.com". An empty Allowlist allows every host if SigningSecret
+	// is set, and no host otherwise, so the handler is never an open proxy.
+	Allowlist []string
+
+	// CacheTTL caches captures in memory for the given duration and lets
+	// browsers and CDNs cache them for as long. Zero disables caching.
+	CacheTTL time.Duration
+
+	// SigningSecret requires every request to be signed with SignHandlerURL,
+	// so only URLs generated by your backend are served. Signatures cover the
+	// path, so a URL signed for one handler is rejected by another handler
+	// sharing the secret.
+	SigningSecret string
+}
+
+// handler serves screenshots through a Client.
+type handler struct {
+	client *Client
+	opts   HandlerOptions
+	cache  *MemoryCache
+}
+
+// NewHandler returns an http.Handler that serves screenshots captured with c,
+// so frontends can embed captures without access to the API key. Requests
+// are GET requests with the capture options as query parameters:
+//
+//	url       the page to capture (required)
+//	width     viewport width in pixels
+//	height    viewport height in pixels
+//	format    png, jpeg or webp
+//	quality   JPEG/WebP quality (1-100)
+//	fullPage  true to capture the full page
+//
+// Example:
+//
+//	http.Handle("/screenshot", screencraft.NewHandler(client, screencraft.HandlerOptions{
+//	    Allowlist: []string{"example.com", "*.example.com"},
+//	    CacheTTL:  time.Hour,
+//	}))
+func NewHandler(c *Client, opts HandlerOptions) http.Handler {
+	h := &handler{client: c, opts: opts}
+	if opts.CacheTTL > 0 {
+		h.cache = NewMemoryCache(DefaultCacheSize)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if h.opts.SigningSecret != "" {
+		if err := verifyHandlerQuery(requestPath(r), query, h.opts.SigningSecret, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	opts, err := parseHandlerQuery(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.allowed(opts.URL) {
+		http.Error(w, "host not allowed", http.StatusForbidden)
+		return
+	}
+
+	result, err := h.screenshot(r, opts)
+	if err != nil {
+		// API error details are not passed on to the frontend
+		switch {
+		case IsValidationError(err):
+			http.Error(w, "invalid request", http.StatusBadRequest)
+		case IsRateLimitError(err):
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		default:
+			http.Error(w, "capture failed", http.StatusBadGateway)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(result.Data)))
+	if h.opts.CacheTTL > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.opts.CacheTTL.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	if r.Method == http.MethodGet {
+		w.Write(result.Data)
+	}
+}
+
+// screenshot captures opts, serving it from the handler's cache if possible.
+func (h *handler) screenshot(r *http.Request, opts *ScreenshotOptions) (*ScreenshotResult, error) {
+	key := OptionsFingerprint(opts)
+	if h.cache != nil {
+		if cached, ok := h.cache.Get(key); ok {
+			return cached.(*ScreenshotResult), nil
+		}
+	}
+
+	result, err := h.client.Screenshot(r.Context(), opts)
+	if err != nil {
+		return nil, err
+	}
+	if h.cache != nil && len(result.Data) > 0 {
+		h.cache.Set(key, result, h.opts.CacheTTL)
+	}
+	return result, nil
+}
+
+// allowed reports whether the host of target is in the allowlist. Without
+// an allowlist, only signed requests can reach any host.
+func (h *handler) allowed(target string) bool {
+	if len(h.opts.Allowlist) == 0 {
+		return h.opts.SigningSecret != ""
+	}
+
+	normalized, err := NormalizeTargetURL(target)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(normalized)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+
+	for _, pattern := range h.opts.Allowlist {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHandlerQuery builds screenshot options from handler query parameters.
+func parseHandlerQuery(query url.Values) (*ScreenshotOptions, error) {
+	opts := &ScreenshotOptions{URL: query.Get("url")}
+	if opts.URL == "" {
+		return nil, ErrMissingURL
+	}
+
+	width, err := queryInt(query, "width")
+	if err != nil {
+		return nil, err
+	}
+	height, err := queryInt(query, "height")
+	if err != nil {
+		return nil, err
+	}
+	if width > 0 || height > 0 {
+		opts.Viewport = &Viewport{Width: width, Height: height}
+	}
+
+	if f := query.Get("format"); f != "" {
+		format, err := ParseFormat(f)
+		if err != nil {
+			return nil, err
+		}
+		opts.Format = format
+	}
+
+	if opts.Quality, err = queryInt(query, "quality"); err != nil {
+		return nil, err
+	}
+
+	if v := query.Get("fullPage"); v != "" {
+		fullPage, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, NewValidationError("fullPage", "must be true or false", "format").Error
+		}
+		opts.FullPage = fullPage
+	}
+
+	if err := ValidateScreenshotOptions(opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// queryInt parses an optional integer query parameter.
+func queryInt(query url.Values, name string) (int, error) {
+	v := query.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, NewValidationError(name, "must be an integer", "format").Error
+	}
+	return n, nil
+}
+
+// SignHandlerURL signs a URL of a handler created by NewHandler with
+// HandlerOptions.SigningSecret, so it is accepted until expiry. The URL must
+// have the path the handler is requested at, e.g. "/screenshot".
+//
+// Example:
+//
+//	src, err := screencraft.SignHandlerURL("/screenshot?url=https://example.com&width=1200", secret, time.Hour)
+func SignHandlerURL(rawURL, secret string, expiry time.Duration) (string, error) {
+	if secret == "" {
+		return "", ErrMissingSigningKey
+	}
+	if expiry <= 0 {
+		return "", NewValidationError("expiry", "must be positive", "range").Error
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("screencraft: invalid handler URL: %w", err)
+	}
+
+	query := u.Query()
+	query.Del("signature")
+	query.Set("expires", strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	query.Set("signature", signURL(signedPath(u.Path), query, secret))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// verifyHandlerQuery checks the signature and expiry of handler query
+// parameters for a request to path.
+func verifyHandlerQuery(path string, query url.Values, secret string, now time.Time) error {
+	signature := query.Get("signature")
+	if signature == "" {
+		return errors.New("missing signature")
+	}
+
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return errors.New("missing expiry")
+	}
+
+	unsigned := url.Values{}
+	for key, values := range query {
+		if key != "signature" {
+			unsigned[key] = values
+		}
+	}
+	if !hmac.Equal([]byte(signURL(signedPath(path), unsigned, secret)), []byte(signature)) {
+		return errors.New("invalid signature")
+	}
+
+	if now.Unix() > expires {
+		return errors.New("URL expired")
+	}
+	return nil
+}
+
+// requestPath returns the path a request was sent to, before any rewriting
+// by http.StripPrefix, so signatures match the URL the client requested.
+func requestPath(r *http.Request) string {
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		return u.Path
+	}
+	return r.URL.Path
+}
+
+// signedPath returns the path covered by a handler URL signature.
+func signedPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
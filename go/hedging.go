@@ -0,0 +1,139 @@
+package screencraft
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+)
+
+// idempotencyKeyHeader identifies duplicate requests to the API, so that a
+// hedged request is only processed and billed once.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// WithHedging enables hedged requests: if an attempt has not received a
+// response within delay, an identical request is sent and whichever responds
+// first is used, while the other is canceled. Both, and any retries of the
+// call, carry the same Idempotency-Key header so the API processes and bills
+// the call once.
+//
+// Hedging trades extra load for lower tail latency. The hedged copy does not
+// count against WithRateLimit or WithMaxConcurrentRequests. Zero or less
+// disables hedging.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithHedging(5*time.Second))
+func WithHedging(delay time.Duration) Option {
+	return func(c *Client) {
+		c.hedgeDelay = delay
+	}
+}
+
+// newIdempotencyKey returns a random idempotency key.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// hedgeResult is the outcome of one of the requests of a hedged attempt.
+type hedgeResult struct {
+	index  int
+	resp   *http.Response
+	err    error
+	cancel context.CancelFunc
+}
+
+// send performs req, hedging it if enabled.
+func (c *Client) send(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	if c.hedgeDelay <= 0 {
+		return httpClient.Do(req)
+	}
+
+	results := make(chan hedgeResult, 2)
+	var cancels [2]context.CancelFunc
+	do := func(index int, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		cancels[index] = cancel
+		go func() {
+			resp, err := httpClient.Do(r.WithContext(ctx))
+			results <- hedgeResult{index: index, resp: resp, err: err, cancel: cancel}
+		}()
+	}
+
+	do(0, req)
+
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	select {
+	case r := <-results:
+		return r.finish()
+	case <-req.Context().Done():
+		r := <-results
+		return r.finish()
+	case <-timer.C:
+	}
+
+	hedge := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			r := <-results
+			return r.finish()
+		}
+		hedge.Body = body
+	}
+	c.logf("No response after %s, sending hedged request", c.hedgeDelay)
+	do(1, hedge)
+
+	first := <-results
+	if first.err != nil {
+		second := <-results
+		if second.err != nil {
+			second.cancel()
+			return first.finish()
+		}
+		first.cancel()
+		return second.finish()
+	}
+
+	// Cancel the slower request and discard its response
+	cancels[1-first.index]()
+	go func() {
+		if other := <-results; other.resp != nil {
+			other.resp.Body.Close()
+		}
+	}()
+
+	return first.finish()
+}
+
+// finish returns the result's response, whose body cancels the request's
+// context when closed.
+func (r hedgeResult) finish() (*http.Response, error) {
+	if r.err != nil {
+		r.cancel()
+		return nil, r.err
+	}
+	r.resp.Body = &cancelingBody{ReadCloser: r.resp.Body, cancel: r.cancel}
+	return r.resp, nil
+}
+
+// cancelingBody cancels a request's context when its body is closed.
+type cancelingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the request's context.
+func (b *cancelingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,97 @@
+package screencraft
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RequestMiddleware inspects or modifies a request before it is sent. A
+// non-nil error aborts the call without sending the request.
+type RequestMiddleware func(req *http.Request) error
+
+// WithRequestMiddleware adds middleware run before each attempt, including
+// retries, after the SDK has set its own headers. Middleware runs in the
+// order it was added; the option can be used several times.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithRequestMiddleware(func(req *http.Request) error {
+//	        req.Header.Set("Proxy-Authorization", "Bearer "+proxyToken())
+//	        return nil
+//	    }),
+//	)
+func WithRequestMiddleware(middleware ...RequestMiddleware) Option {
+	return func(c *Client) {
+		c.requestMiddleware = append(c.requestMiddleware, middleware...)
+	}
+}
+
+// runRequestMiddleware applies the client's request middleware to req.
+func (c *Client) runRequestMiddleware(req *http.Request) error {
+	for _, mw := range c.requestMiddleware {
+		if err := mw(req); err != nil {
+			return fmt.Errorf("screencraft: request middleware: %w", err)
+		}
+	}
+	return nil
+}
+
+// ResponseHook observes the outcome of a request attempt. resp is nil if no
+// response was received; err is the error the attempt resulted in, if any.
+// attempt is 1 for the first attempt. Hooks must not read or close the
+// response body.
+type ResponseHook func(resp *http.Response, err error, attempt int)
+
+// WithResponseHook adds a hook run after each attempt, including retries, for
+// metrics, audit logging or alerting. Hooks run in the order they were added;
+// the option can be used several times.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithResponseHook(func(resp *http.Response, err error, attempt int) {
+//	        if resp != nil && resp.StatusCode >= 500 {
+//	            serverErrors.Inc()
+//	        }
+//	    }),
+//	)
+func WithResponseHook(hooks ...ResponseHook) Option {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hooks...)
+	}
+}
+
+// runResponseHooks passes the outcome of an attempt to the client's hooks.
+func (c *Client) runResponseHooks(resp *http.Response, err error, attempt int) {
+	for _, hook := range c.responseHooks {
+		hook(resp, err, attempt)
+	}
+}
+
+// WithOnRetry adds a callback run whenever the client decides to retry a
+// request, before it waits. attempt is the number of the upcoming attempt
+// (2 for the first retry), err is the error that caused the retry and wait
+// is the backoff about to be waited. The option can be used several times.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithOnRetry(func(attempt int, err error, wait time.Duration) {
+//	        log.Printf("screencraft: attempt %d in %s after: %v", attempt, wait, err)
+//	        retries.Inc()
+//	    }),
+//	)
+func WithOnRetry(fn func(attempt int, err error, wait time.Duration)) Option {
+	return func(c *Client) {
+		c.onRetry = append(c.onRetry, fn)
+	}
+}
+
+// runOnRetry passes a retry decision to the client's callbacks.
+func (c *Client) runOnRetry(attempt int, err error, wait time.Duration) {
+	for _, fn := range c.onRetry {
+		fn(attempt, err, wait)
+	}
+}
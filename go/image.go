@@ -0,0 +1,69 @@
+package screencraft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// ErrUnsupportedImageFormat is returned when a screenshot cannot be decoded
+// because no decoder is registered for its format.
+var ErrUnsupportedImageFormat = errors.New("screencraft: unsupported image format")
+
+// Image decodes the screenshot. PNG and JPEG are supported out of the box;
+// WebP requires registering a decoder by importing golang.org/x/image/webp.
+//
+// Example:
+//
+//	img, err := result.Image()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(img.At(0, 0))
+func (r *ScreenshotResult) Image() (image.Image, error) {
+	if err := r.checkImage(); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(r.Data))
+	if err != nil {
+		return nil, r.decodeError(err)
+	}
+	return img, nil
+}
+
+// Config decodes the dimensions and color model of the screenshot without
+// decoding the whole image.
+func (r *ScreenshotResult) Config() (image.Config, error) {
+	if err := r.checkImage(); err != nil {
+		return image.Config{}, err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(r.Data))
+	if err != nil {
+		return image.Config{}, r.decodeError(err)
+	}
+	return cfg, nil
+}
+
+// checkImage reports an error if the result holds no image data.
+func (r *ScreenshotResult) checkImage() error {
+	if len(r.Data) == 0 {
+		return fmt.Errorf("screencraft: screenshot has no image data")
+	}
+	if r.ContentType != "" && !strings.HasPrefix(r.ContentType, "image/") {
+		return fmt.Errorf("%w: %s", ErrUnsupportedImageFormat, r.ContentType)
+	}
+	return nil
+}
+
+// decodeError wraps an image decoding error.
+func (r *ScreenshotResult) decodeError(err error) error {
+	if !errors.Is(err, image.ErrFormat) {
+		return fmt.Errorf("screencraft: failed to decode image: %w", err)
+	}
+	if r.ContentType == "image/webp" {
+		return fmt.Errorf("%w: image/webp (import golang.org/x/image/webp to register a decoder)", ErrUnsupportedImageFormat)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedImageFormat, r.ContentType)
+}
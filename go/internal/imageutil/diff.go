@@ -0,0 +1,103 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+)
+
+// maxYIQDelta is the largest possible squared YIQ distance between two
+// colors.
+const maxYIQDelta = 35215
+
+// Diff is the result of comparing two images.
+type Diff struct {
+	// Pixels is the number of differing pixels. Pixels covered by only one
+	// of the images count as differing.
+	Pixels int
+	// Ratio is the fraction of differing pixels (0-1) of the larger area.
+	Ratio float64
+	// Bounds is the smallest rectangle containing all differing pixels.
+	Bounds image.Rectangle
+	// SizeMismatch is true if the images have different dimensions.
+	SizeMismatch bool
+	// Image highlights differing pixels in red over a faded copy of a, if
+	// requested and the images differ.
+	Image *image.RGBA
+}
+
+// Compare compares a and b pixel by pixel using a perceptual (YIQ) color
+// difference. Pixels whose difference exceeds threshold (0-1) count as
+// differing. Images of different sizes are compared from their top-left
+// corners.
+func Compare(a, b image.Image, threshold float64, highlight bool) Diff {
+	ba, bb := a.Bounds(), b.Bounds()
+	width, height := max(ba.Dx(), bb.Dx()), max(ba.Dy(), bb.Dy())
+
+	d := Diff{SizeMismatch: ba.Dx() != bb.Dx() || ba.Dy() != bb.Dy()}
+	var out *image.RGBA
+	if highlight {
+		out = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+
+	maxDelta := maxYIQDelta * threshold * threshold
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			inA := x < ba.Dx() && y < ba.Dy()
+			inB := x < bb.Dx() && y < bb.Dy()
+
+			var ca color.Color = color.Transparent
+			if inA {
+				ca = a.At(ba.Min.X+x, ba.Min.Y+y)
+			}
+			if inA && inB && yiqDelta(ca, b.At(bb.Min.X+x, bb.Min.Y+y)) <= maxDelta {
+				if out != nil {
+					out.Set(x, y, faded(ca))
+				}
+				continue
+			}
+
+			d.Pixels++
+			d.Bounds = d.Bounds.Union(image.Rect(x, y, x+1, y+1))
+			if out != nil {
+				out.Set(x, y, color.RGBA{R: 255, A: 255})
+			}
+		}
+	}
+
+	if total := width * height; total > 0 {
+		d.Ratio = float64(d.Pixels) / float64(total)
+	}
+	if d.Pixels > 0 {
+		d.Image = out
+	}
+	return d
+}
+
+// yiqDelta returns the squared perceptual distance between two colors in
+// YIQ space, after blending them onto white.
+func yiqDelta(a, b color.Color) float64 {
+	ra, ga, ba := blendWhite(a)
+	rb, gb, bb := blendWhite(b)
+
+	y := rgbToY(ra, ga, ba) - rgbToY(rb, gb, bb)
+	i := rgbToI(ra, ga, ba) - rgbToI(rb, gb, bb)
+	q := rgbToQ(ra, ga, ba) - rgbToQ(rb, gb, bb)
+	return 0.5053*y*y + 0.299*i*i + 0.1957*q*q
+}
+
+// blendWhite returns the 8-bit channels of c blended onto white.
+func blendWhite(c color.Color) (float64, float64, float64) {
+	r, g, b, a := c.RGBA()
+	white := 255 * (1 - float64(a)/0xffff)
+	return float64(r>>8) + white, float64(g>>8) + white, float64(b>>8) + white
+}
+
+func rgbToY(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func rgbToI(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func rgbToQ(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
+
+// faded returns a light gray version of c for unchanged diff image pixels.
+func faded(c color.Color) color.Color {
+	g := color.GrayModel.Convert(c).(color.Gray)
+	return color.Gray{Y: 192 + g.Y/4}
+}
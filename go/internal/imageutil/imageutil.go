@@ -0,0 +1,239 @@
+// Package imageutil contains image helpers shared by the SDK packages.
+package imageutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	// Register decoders for the formats supported by the standard library.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ErrUnsupportedFormat is returned for image formats the standard library
+// cannot decode (such as WebP).
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Decode decodes PNG, JPEG or GIF image data.
+func Decode(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// ToRGBA returns img as an *image.RGBA with bounds starting at the origin.
+func ToRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// Filter selects the resampling method of ResizeWith.
+type Filter int
+
+const (
+	// Area averages all source pixels covered by a destination pixel.
+	Area Filter = iota
+	// Bilinear interpolates between the four nearest source pixels.
+	Bilinear
+	// NearestNeighbor picks the closest source pixel.
+	NearestNeighbor
+)
+
+// FitMode selects how Fit maps an image onto a target box.
+type FitMode int
+
+const (
+	// FitCover fills the box, cropping what overflows.
+	FitCover FitMode = iota
+	// FitContain fits inside the box, keeping the aspect ratio.
+	FitContain
+	// FitFill stretches to the exact box.
+	FitFill
+)
+
+// Resize scales img to width x height using area averaging, which gives good
+// results for both down- and upscaling of screenshots.
+func Resize(img image.Image, width, height int) *image.RGBA {
+	return ResizeWith(img, width, height, Area)
+}
+
+// ResizeWith scales img to width x height using the given filter.
+func ResizeWith(img image.Image, width, height int, filter Filter) *image.RGBA {
+	src := ToRGBA(img)
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if sw == 0 || sh == 0 || width <= 0 || height <= 0 {
+		return dst
+	}
+
+	switch filter {
+	case NearestNeighbor:
+		resizeNearest(dst, src)
+	case Bilinear:
+		resizeBilinear(dst, src)
+	default:
+		resizeArea(dst, src)
+	}
+	return dst
+}
+
+// resizeArea resamples src into dst by area averaging.
+func resizeArea(dst, src *image.RGBA) {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	width, height := dst.Bounds().Dx(), dst.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := (y + 1) * sh / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := (x + 1) * sw / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					b += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+}
+
+// resizeNearest resamples src into dst by picking the nearest pixel.
+func resizeNearest(dst, src *image.RGBA) {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	width, height := dst.Bounds().Dx(), dst.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		sy := (2*y + 1) * sh / (2 * height)
+		for x := 0; x < width; x++ {
+			sx := (2*x + 1) * sw / (2 * width)
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:])
+		}
+	}
+}
+
+// resizeBilinear resamples src into dst by bilinear interpolation.
+func resizeBilinear(dst, src *image.RGBA) {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	width, height := dst.Bounds().Dx(), dst.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		fy := (float64(y)+0.5)*float64(sh)/float64(height) - 0.5
+		y0, wy := split(fy, sh)
+		y1 := min(y0+1, sh-1)
+
+		for x := 0; x < width; x++ {
+			fx := (float64(x)+0.5)*float64(sw)/float64(width) - 0.5
+			x0, wx := split(fx, sw)
+			x1 := min(x0+1, sw-1)
+
+			i := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				p00 := float64(src.Pix[y0*src.Stride+x0*4+c])
+				p01 := float64(src.Pix[y0*src.Stride+x1*4+c])
+				p10 := float64(src.Pix[y1*src.Stride+x0*4+c])
+				p11 := float64(src.Pix[y1*src.Stride+x1*4+c])
+				top := p00 + (p01-p00)*wx
+				bottom := p10 + (p11-p10)*wx
+				dst.Pix[i+c] = uint8(top + (bottom-top)*wy + 0.5)
+			}
+		}
+	}
+}
+
+// split returns the integer source coordinate below f, clamped to [0, n),
+// and the interpolation weight towards the next coordinate.
+func split(f float64, n int) (int, float64) {
+	if f <= 0 {
+		return 0, 0
+	}
+	i := int(f)
+	if i >= n-1 {
+		return n - 1, 0
+	}
+	return i, f - float64(i)
+}
+
+// FitSize returns the output size of fitting an sw x sh image into a
+// width x height box. A zero width or height follows from the aspect ratio.
+func FitSize(sw, sh, width, height int, mode FitMode) (int, int) {
+	if sw <= 0 || sh <= 0 {
+		return 0, 0
+	}
+	if width <= 0 && height <= 0 {
+		return sw, sh
+	}
+	if width <= 0 {
+		return max(1, (sw*height+sh/2)/sh), height
+	}
+	if height <= 0 {
+		return width, max(1, (sh*width+sw/2)/sw)
+	}
+
+	if mode == FitContain {
+		scale := math.Min(float64(width)/float64(sw), float64(height)/float64(sh))
+		return max(1, int(math.Round(float64(sw)*scale))), max(1, int(math.Round(float64(sh)*scale)))
+	}
+	return width, height
+}
+
+// Fit resizes img into a width x height box using mode and filter. A zero
+// width or height follows from the aspect ratio.
+func Fit(img image.Image, width, height int, mode FitMode, filter Filter) *image.RGBA {
+	b := img.Bounds()
+	tw, th := FitSize(b.Dx(), b.Dy(), width, height, mode)
+	if mode != FitCover || width <= 0 || height <= 0 {
+		return ResizeWith(img, tw, th, filter)
+	}
+
+	// Scale to cover the box, then crop the center
+	scale := math.Max(float64(width)/float64(b.Dx()), float64(height)/float64(b.Dy()))
+	sw := max(width, int(math.Ceil(float64(b.Dx())*scale)))
+	sh := max(height, int(math.Ceil(float64(b.Dy())*scale)))
+	scaled := ResizeWith(img, sw, sh, filter)
+
+	x0 := (sw - width) / 2
+	y0 := (sh - height) / 2
+	return ToRGBA(scaled.SubImage(image.Rect(x0, y0, x0+width, y0+height)))
+}
+
+// Fill fills the rectangle r of dst with c.
+func Fill(dst draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(dst, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
@@ -0,0 +1,218 @@
+package pdfutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// inheritableKeys are page attributes that may be inherited from the page
+// tree and must be copied onto pages moved to a new tree.
+var inheritableKeys = []Name{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// Pages returns the page objects of d in document order, each with its
+// inherited attributes applied.
+func (d *Document) Pages() ([]Ref, []Dict, error) {
+	root, err := d.Resolve(d.Trailer["Root"])
+	if err != nil {
+		return nil, nil, err
+	}
+	catalog, ok := root.(Dict)
+	if !ok {
+		return nil, nil, errors.New("pdfutil: invalid document catalog")
+	}
+
+	var refs []Ref
+	var pages []Dict
+	visited := make(map[int]bool)
+
+	var walk func(node Object, inherited Dict) error
+	walk = func(node Object, inherited Dict) error {
+		ref, isRef := node.(Ref)
+		if isRef {
+			if visited[ref.Num] {
+				return errors.New("pdfutil: cycle in page tree")
+			}
+			visited[ref.Num] = true
+		}
+
+		obj, err := d.Resolve(node)
+		if err != nil {
+			return err
+		}
+		dict, ok := obj.(Dict)
+		if !ok {
+			return nil
+		}
+
+		if kids, ok := dict["Kids"]; ok && dict["Type"] != Name("Page") {
+			next := inherited.Clone()
+			for _, key := range inheritableKeys {
+				if v, ok := dict[key]; ok {
+					next[key] = v
+				}
+			}
+
+			kidsObj, err := d.Resolve(kids)
+			if err != nil {
+				return err
+			}
+			arr, _ := kidsObj.(Array)
+			for _, kid := range arr {
+				if err := walk(kid, next); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if !isRef {
+			return errors.New("pdfutil: page is not an indirect object")
+		}
+
+		page := dict.Clone()
+		for _, key := range inheritableKeys {
+			if _, ok := page[key]; !ok {
+				if v, ok := inherited[key]; ok {
+					page[key] = v
+				}
+			}
+		}
+		refs = append(refs, ref)
+		pages = append(pages, page)
+		return nil
+	}
+
+	if err := walk(catalog["Pages"], Dict{}); err != nil {
+		return nil, nil, err
+	}
+	return refs, pages, nil
+}
+
+// Merge concatenates the pages of several PDF documents into a new
+// document. Document-level features such as outlines, named destinations
+// and interactive forms are not carried over. It returns the merged
+// document and its page count.
+func Merge(docs ...[]byte) ([]byte, int, error) {
+	const (
+		catalogNum = 1
+		pagesNum   = 2
+	)
+
+	objects := map[int]Object{}
+	nextNum := pagesNum + 1
+	var kids Array
+
+	for i, data := range docs {
+		d, err := Parse(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("document %d: %w", i+1, err)
+		}
+
+		pageRefs, pageDicts, err := d.Pages()
+		if err != nil {
+			return nil, 0, fmt.Errorf("document %d: %w", i+1, err)
+		}
+
+		// Copy every object reachable from the pages under new numbers
+		renumbered := map[int]int{}
+		var queue []int
+		mapRef := func(old int) int {
+			if n, ok := renumbered[old]; ok {
+				return n
+			}
+			n := nextNum
+			nextNum++
+			renumbered[old] = n
+			queue = append(queue, old)
+			return n
+		}
+
+		pageByNum := map[int]Dict{}
+		for j, ref := range pageRefs {
+			pageByNum[ref.Num] = pageDicts[j]
+			kids = append(kids, Ref{Num: mapRef(ref.Num)})
+		}
+
+		for len(queue) > 0 {
+			old := queue[0]
+			queue = queue[1:]
+
+			var obj Object
+			if page, ok := pageByNum[old]; ok {
+				page = page.Clone()
+				delete(page, "Parent")
+				obj = page
+			} else if obj, err = d.Object(old); err != nil {
+				return nil, 0, fmt.Errorf("document %d: %w", i+1, err)
+			}
+
+			obj = renumber(obj, mapRef)
+			if page, ok := obj.(Dict); ok && pageByNum[old] != nil {
+				page["Parent"] = Ref{Num: pagesNum}
+			}
+			objects[renumbered[old]] = obj
+		}
+	}
+
+	objects[catalogNum] = Dict{"Type": Name("Catalog"), "Pages": Ref{Num: pagesNum}}
+	objects[pagesNum] = Dict{
+		"Type":  Name("Pages"),
+		"Kids":  kids,
+		"Count": Number(strconv.Itoa(len(kids))),
+	}
+
+	return writeDocument(objects, nextNum), len(kids), nil
+}
+
+// renumber returns a copy of obj with references mapped to new numbers.
+func renumber(obj Object, mapRef func(int) int) Object {
+	switch v := obj.(type) {
+	case Ref:
+		return Ref{Num: mapRef(v.Num)}
+	case Array:
+		arr := make(Array, len(v))
+		for i, elem := range v {
+			arr[i] = renumber(elem, mapRef)
+		}
+		return arr
+	case Dict:
+		dict := make(Dict, len(v))
+		for k, elem := range v {
+			dict[k] = renumber(elem, mapRef)
+		}
+		return dict
+	case *Stream:
+		return &Stream{Dict: renumber(v.Dict, mapRef).(Dict), Data: v.Data}
+	}
+	return obj
+}
+
+// writeDocument serializes objects 1..size-1 with a classic xref table.
+// Object 1 must be the catalog.
+func writeDocument(objects map[int]Object, size int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, size)
+	for num := 1; num < size; num++ {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", num)
+		Write(&buf, objects[num])
+		buf.WriteString("\nendobj\n")
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", size)
+	buf.WriteString("0000000000 65535 f \n")
+	for num := 1; num < size; num++ {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offsets[num])
+	}
+
+	buf.WriteString("trailer\n")
+	Write(&buf, Dict{"Size": Number(strconv.Itoa(size)), "Root": Ref{Num: 1}})
+	fmt.Fprintf(&buf, "\nstartxref\n%d\n%%%%EOF\n", xrefOffset)
+
+	return buf.Bytes()
+}
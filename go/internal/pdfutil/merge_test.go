@@ -0,0 +1,87 @@
+package pdfutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergeRoundTrip(t *testing.T) {
+	first := onePagePDF("BT /F1 12 Tf (first) Tj ET")
+	second := xrefStreamPDF()
+
+	merged, n, err := Merge(first, second)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Merge returned %d pages, want 2", n)
+	}
+
+	d, err := Parse(merged)
+	if err != nil {
+		t.Fatalf("Parse(merged): %v", err)
+	}
+	refs, pages, err := d.Pages()
+	if err != nil {
+		t.Fatalf("Pages: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("merged document has %d pages, want 2", len(pages))
+	}
+
+	// Inherited attributes are copied onto the moved pages
+	wantMedia := []Object{
+		Array{Number("0"), Number("0"), Number("612"), Number("792")},
+		Array{Number("0"), Number("0"), Number("200"), Number("100")},
+	}
+	for i, page := range pages {
+		if !reflect.DeepEqual(page["MediaBox"], wantMedia[i]) {
+			t.Errorf("page %d MediaBox = %v, want %v", i+1, page["MediaBox"], wantMedia[i])
+		}
+		if page["Parent"] != (Ref{Num: 2}) {
+			t.Errorf("page %d Parent = %v, want the merged page tree", i+1, page["Parent"])
+		}
+	}
+	if pages[1]["Rotate"] != Number("90") {
+		t.Errorf("page 2 Rotate = %v, want 90", pages[1]["Rotate"])
+	}
+
+	// Page content survives renumbering
+	contents, err := d.Resolve(pages[0]["Contents"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, ok := contents.(*Stream)
+	if !ok {
+		t.Fatalf("page 1 Contents is %T, want *Stream", contents)
+	}
+	if got := string(stream.Data); got != "BT /F1 12 Tf (first) Tj ET" {
+		t.Errorf("page 1 content = %q", got)
+	}
+	if refs[0] == refs[1] {
+		t.Errorf("pages share object %d", refs[0].Num)
+	}
+
+	// The merged output merges again
+	again, n, err := Merge(merged, first)
+	if err != nil {
+		t.Fatalf("Merge(merged, first): %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Merge(merged, first) returned %d pages, want 3", n)
+	}
+	if _, err := Parse(again); err != nil {
+		t.Errorf("Parse(again): %v", err)
+	}
+}
+
+func TestMergeInvalidDocument(t *testing.T) {
+	_, _, err := Merge(onePagePDF("BT ET"), []byte("not a pdf"))
+	if !errors.Is(err, ErrNotPDF) {
+		t.Errorf("Merge error = %v, want ErrNotPDF", err)
+	}
+	if err == nil || err.Error() != "document 2: "+ErrNotPDF.Error() {
+		t.Errorf("Merge error = %v, want it to name document 2", err)
+	}
+}
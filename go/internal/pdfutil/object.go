@@ -0,0 +1,122 @@
+// Package pdfutil reads and writes the PDF object model, as far as needed to
+// restructure documents (e.g. merge them) without rendering.
+package pdfutil
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Object is a PDF object: nil (null), Bool, Number, Name, String, Array,
+// Dict, Ref, *Stream or Keyword.
+type Object interface{}
+
+// Bool is a PDF boolean.
+type Bool bool
+
+// Number is a PDF integer or real, kept in its original textual form.
+type Number string
+
+// Name is a PDF name without the leading slash, in its original (possibly
+// #-escaped) form.
+type Name string
+
+// String is a literal or hex string, kept in its original encoded form
+// including the delimiters.
+type String []byte
+
+// Array is a PDF array.
+type Array []Object
+
+// Dict is a PDF dictionary.
+type Dict map[Name]Object
+
+// Ref is an indirect object reference.
+type Ref struct {
+	Num int
+	Gen int
+}
+
+// Stream is a stream object. Data is the encoded stream content.
+type Stream struct {
+	Dict Dict
+	Data []byte
+}
+
+// Keyword is a bare token that is not an object, such as "obj" or "R".
+type Keyword string
+
+// Int returns the integer value of n.
+func (n Number) Int() (int, bool) {
+	v, err := strconv.Atoi(string(n))
+	return v, err == nil
+}
+
+// Clone returns a shallow copy of d.
+func (d Dict) Clone() Dict {
+	c := make(Dict, len(d))
+	for k, v := range d {
+		c[k] = v
+	}
+	return c
+}
+
+// Write writes the serialized form of obj to buf.
+func Write(buf *bytes.Buffer, obj Object) {
+	switch v := obj.(type) {
+	case nil:
+		buf.WriteString("null")
+	case Bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case Number:
+		buf.WriteString(string(v))
+	case Name:
+		buf.WriteByte('/')
+		buf.WriteString(string(v))
+	case String:
+		buf.Write(v)
+	case Keyword:
+		buf.WriteString(string(v))
+	case Ref:
+		fmt.Fprintf(buf, "%d %d R", v.Num, v.Gen)
+	case Array:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			Write(buf, elem)
+		}
+		buf.WriteByte(']')
+	case Dict:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+
+		buf.WriteString("<<")
+		for _, k := range keys {
+			buf.WriteByte('/')
+			buf.WriteString(k)
+			buf.WriteByte(' ')
+			Write(buf, v[Name(k)])
+		}
+		buf.WriteString(">>")
+	case *Stream:
+		dict := v.Dict.Clone()
+		dict["Length"] = Number(strconv.Itoa(len(v.Data)))
+		Write(buf, dict)
+		buf.WriteString("\nstream\n")
+		buf.Write(v.Data)
+		buf.WriteString("\nendstream")
+	default:
+		panic(fmt.Sprintf("pdfutil: cannot write %T", obj))
+	}
+}
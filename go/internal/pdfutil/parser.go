@@ -0,0 +1,276 @@
+package pdfutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// errUnexpectedEOF is returned when the data ends inside an object.
+var errUnexpectedEOF = errors.New("pdfutil: unexpected end of data")
+
+// parser tokenizes and parses PDF objects.
+type parser struct {
+	data []byte
+	pos  int
+}
+
+// isWhite reports whether c is PDF whitespace.
+func isWhite(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+// isDelim reports whether c is a PDF delimiter.
+func isDelim(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace and comments.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case isWhite(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// readRegular reads a run of regular characters.
+func (p *parser) readRegular() string {
+	start := p.pos
+	for p.pos < len(p.data) && !isWhite(p.data[p.pos]) && !isDelim(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// parseObject parses the next object. Bare keywords are returned as Keyword.
+func (p *parser) parseObject() (Object, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, errUnexpectedEOF
+	}
+
+	switch c := p.data[p.pos]; c {
+	case '/':
+		p.pos++
+		return Name(p.readRegular()), nil
+
+	case '(':
+		return p.parseLiteralString()
+
+	case '<':
+		if p.pos+1 < len(p.data) && p.data[p.pos+1] == '<' {
+			p.pos += 2
+			return p.parseDict()
+		}
+		end := bytes.IndexByte(p.data[p.pos:], '>')
+		if end < 0 {
+			return nil, errUnexpectedEOF
+		}
+		s := String(append([]byte(nil), p.data[p.pos:p.pos+end+1]...))
+		p.pos += end + 1
+		return s, nil
+
+	case '[':
+		p.pos++
+		arr := Array{}
+		for {
+			p.skipSpace()
+			if p.pos >= len(p.data) {
+				return nil, errUnexpectedEOF
+			}
+			if p.data[p.pos] == ']' {
+				p.pos++
+				return arr, nil
+			}
+			elem, err := p.parseObject()
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, elem)
+		}
+
+	case ']', '>', ')', '{', '}':
+		return nil, fmt.Errorf("pdfutil: unexpected %q at offset %d", c, p.pos)
+	}
+
+	tok := p.readRegular()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("pdfutil: unexpected %q at offset %d", p.data[p.pos], p.pos)
+	case "true":
+		return Bool(true), nil
+	case "false":
+		return Bool(false), nil
+	case "null":
+		return nil, nil
+	}
+
+	if isInteger(tok) {
+		// An integer may start an indirect reference "num gen R"
+		save := p.pos
+		p.skipSpace()
+		if gen := p.readRegular(); isInteger(gen) {
+			p.skipSpace()
+			if p.readRegular() == "R" {
+				num, _ := strconv.Atoi(tok)
+				g, _ := strconv.Atoi(gen)
+				return Ref{Num: num, Gen: g}, nil
+			}
+		}
+		p.pos = save
+		return Number(tok), nil
+	}
+
+	if _, err := strconv.ParseFloat(tok, 64); err == nil {
+		return Number(tok), nil
+	}
+
+	return Keyword(tok), nil
+}
+
+// parseLiteralString parses a literal string, keeping its raw form.
+func (p *parser) parseLiteralString() (Object, error) {
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return String(append([]byte(nil), p.data[start:p.pos]...)), nil
+			}
+		}
+		p.pos++
+	}
+	return nil, errUnexpectedEOF
+}
+
+// parseDict parses a dictionary after its opening "<<".
+func (p *parser) parseDict() (Object, error) {
+	dict := Dict{}
+	for {
+		p.skipSpace()
+		if p.pos+1 >= len(p.data) {
+			return nil, errUnexpectedEOF
+		}
+		if p.data[p.pos] == '>' && p.data[p.pos+1] == '>' {
+			p.pos += 2
+			return dict, nil
+		}
+
+		key, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(Name)
+		if !ok {
+			return nil, fmt.Errorf("pdfutil: dictionary key is %T, not a name, at offset %d", key, p.pos)
+		}
+
+		value, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		dict[name] = value
+	}
+}
+
+// parseIndirect parses an indirect object "num gen obj ... endobj" at the
+// current position, including stream data.
+func (p *parser) parseIndirect() (Ref, Object, error) {
+	var ref Ref
+
+	p.skipSpace()
+	num, err1 := strconv.Atoi(p.readRegular())
+	p.skipSpace()
+	gen, err2 := strconv.Atoi(p.readRegular())
+	p.skipSpace()
+	if err1 != nil || err2 != nil || p.readRegular() != "obj" {
+		return ref, nil, fmt.Errorf("pdfutil: no object at offset %d", p.pos)
+	}
+	ref = Ref{Num: num, Gen: gen}
+
+	obj, err := p.parseObject()
+	if err != nil {
+		return ref, nil, err
+	}
+
+	p.skipSpace()
+	if !bytes.HasPrefix(p.data[p.pos:], []byte("stream")) {
+		return ref, obj, nil
+	}
+
+	dict, ok := obj.(Dict)
+	if !ok {
+		return ref, nil, fmt.Errorf("pdfutil: stream without dictionary in object %d", num)
+	}
+
+	p.pos += len("stream")
+	if p.pos < len(p.data) && p.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+		p.pos++
+	}
+	start := p.pos
+
+	// Trust a direct /Length if "endstream" follows it
+	if n, ok := dict["Length"].(Number); ok {
+		if length, ok := n.Int(); ok && length >= 0 && start+length <= len(p.data) {
+			after := parser{data: p.data, pos: start + length}
+			after.skipSpace()
+			if bytes.HasPrefix(p.data[after.pos:], []byte("endstream")) {
+				p.pos = after.pos + len("endstream")
+				return ref, &Stream{Dict: dict, Data: p.data[start : start+length]}, nil
+			}
+		}
+	}
+
+	end := bytes.Index(p.data[start:], []byte("endstream"))
+	if end < 0 {
+		return ref, nil, fmt.Errorf("pdfutil: unterminated stream in object %d", num)
+	}
+	data := p.data[start : start+end]
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	p.pos = start + end + len("endstream")
+
+	return ref, &Stream{Dict: dict, Data: data}, nil
+}
+
+// isInteger reports whether tok is an unsigned or signed integer.
+func isInteger(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	for i := 0; i < len(tok); i++ {
+		c := tok[i]
+		if (c < '0' || c > '9') && !(i == 0 && (c == '+' || c == '-')) {
+			return false
+		}
+	}
+	return tok != "+" && tok != "-"
+}
@@ -0,0 +1,117 @@
+package pdfutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseObject(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Object
+		wantErr bool
+	}{
+		{name: "null", input: "null", want: nil},
+		{name: "bool", input: "true", want: Bool(true)},
+		{name: "integer", input: "-42", want: Number("-42")},
+		{name: "real", input: "3.14", want: Number("3.14")},
+		{name: "name", input: "/Type", want: Name("Type")},
+		{name: "escaped name", input: "/A#20B", want: Name("A#20B")},
+		{name: "literal string", input: `(a (nested) \) string)`, want: String(`(a (nested) \) string)`)},
+		{name: "hex string", input: "<48656c6c6f>", want: String("<48656c6c6f>")},
+		{name: "keyword", input: "endobj", want: Keyword("endobj")},
+		{name: "reference", input: "12 0 R", want: Ref{Num: 12}},
+		{name: "integer not followed by R", input: "12 0 obj", want: Number("12")},
+		{name: "comment", input: "% comment\n7", want: Number("7")},
+		{
+			name:  "array of references",
+			input: "[1 0 R 2 5 R 3]",
+			want:  Array{Ref{Num: 1}, Ref{Num: 2, Gen: 5}, Number("3")},
+		},
+		{
+			name:  "nested dictionary",
+			input: "<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]/Resources<</Font<<>>>>>>",
+			want: Dict{
+				"Type":      Name("Page"),
+				"Parent":    Ref{Num: 2},
+				"MediaBox":  Array{Number("0"), Number("0"), Number("612"), Number("792")},
+				"Resources": Dict{"Font": Dict{}},
+			},
+		},
+		{name: "empty", input: "  ", wantErr: true},
+		{name: "unterminated array", input: "[1 2", wantErr: true},
+		{name: "unterminated dictionary", input: "<</A 1", wantErr: true},
+		{name: "unterminated string", input: "(abc", wantErr: true},
+		{name: "unterminated hex string", input: "<414243", wantErr: true},
+		{name: "non-name key", input: "<<1 2>>", wantErr: true},
+		{name: "missing value", input: "<</A>>", wantErr: true},
+		{name: "stray delimiter", input: "]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{data: []byte(tt.input)}
+			got, err := p.parseObject()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseObject(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseObject(%q): %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseObject(%q) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIndirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantRef  Ref
+		wantData string
+		wantErr  bool
+	}{
+		{name: "object", input: "4 1 obj\n<</A 1>>\nendobj", wantRef: Ref{Num: 4, Gen: 1}},
+		{name: "stream", input: "5 0 obj\n<</Length 5>>\nstream\nhello\nendstream\nendobj", wantRef: Ref{Num: 5}, wantData: "hello"},
+		{name: "stream with CRLF", input: "5 0 obj\r\n<</Length 5>>\r\nstream\r\nhello\r\nendstream", wantRef: Ref{Num: 5}, wantData: "hello"},
+		{name: "stream with wrong length", input: "5 0 obj\n<</Length 99>>\nstream\nhello\nendstream", wantRef: Ref{Num: 5}, wantData: "hello"},
+		{name: "stream with indirect length", input: "5 0 obj\n<</Length 6 0 R>>\nstream\nhello\nendstream", wantRef: Ref{Num: 5}, wantData: "hello"},
+		{name: "no header", input: "<</A 1>>", wantErr: true},
+		{name: "unterminated stream", input: "5 0 obj\n<<>>\nstream\nhello", wantErr: true},
+		{name: "stream without dictionary", input: "5 0 obj\n[1 2]\nstream\nhello\nendstream", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{data: []byte(tt.input)}
+			ref, obj, err := p.parseIndirect()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseIndirect(%q) = %v, want error", tt.input, obj)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseIndirect(%q): %v", tt.input, err)
+			}
+			if ref != tt.wantRef {
+				t.Errorf("ref = %v, want %v", ref, tt.wantRef)
+			}
+			if tt.wantData != "" {
+				stream, ok := obj.(*Stream)
+				if !ok {
+					t.Fatalf("object is %T, want *Stream", obj)
+				}
+				if string(stream.Data) != tt.wantData {
+					t.Errorf("stream data = %q, want %q", stream.Data, tt.wantData)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,548 @@
+package pdfutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// ErrNotPDF is returned for data that is not a PDF document.
+	ErrNotPDF = errors.New("pdfutil: not a PDF document")
+
+	// ErrEncrypted is returned for encrypted documents.
+	ErrEncrypted = errors.New("pdfutil: encrypted PDFs are not supported")
+)
+
+// xrefEntry locates an object: at a byte offset, or inside an object stream.
+type xrefEntry struct {
+	offset   int
+	inStream bool
+	stream   int
+	index    int
+}
+
+// Document is a parsed PDF document.
+type Document struct {
+	data    []byte
+	xref    map[int]xrefEntry
+	objects map[int]Object
+	objStms map[int]*objectStream
+
+	// Trailer is the document trailer dictionary.
+	Trailer Dict
+}
+
+// objectStream is a decoded object stream.
+type objectStream struct {
+	parser  parser
+	offsets []int
+}
+
+// objectPattern finds indirect object headers when the xref is unusable.
+var objectPattern = regexp.MustCompile(`(?m)(?:^|[\r\n\s])(\d+)\s+(\d+)\s+obj\b`)
+
+// Parse parses a PDF document.
+func Parse(data []byte) (*Document, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\n\f\r "), []byte("%PDF-")) {
+		return nil, ErrNotPDF
+	}
+
+	d := &Document{
+		data:    data,
+		xref:    make(map[int]xrefEntry),
+		objects: make(map[int]Object),
+		objStms: make(map[int]*objectStream),
+	}
+
+	if err := d.readXref(); err != nil || d.Trailer["Root"] == nil {
+		// Damaged or missing xref, reconstruct it by scanning the file
+		d.xref = make(map[int]xrefEntry)
+		d.Trailer = nil
+		if err := d.scanObjects(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := d.Trailer["Encrypt"]; ok {
+		return nil, ErrEncrypted
+	}
+
+	return d, nil
+}
+
+// readXref reads the cross-reference sections starting at startxref,
+// following /Prev links to older sections.
+func (d *Document) readXref() error {
+	i := bytes.LastIndex(d.data, []byte("startxref"))
+	if i < 0 {
+		return errors.New("pdfutil: startxref not found")
+	}
+	p := parser{data: d.data, pos: i + len("startxref")}
+	p.skipSpace()
+	offset, err := strconv.Atoi(p.readRegular())
+	if err != nil {
+		return fmt.Errorf("pdfutil: invalid startxref: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	for offset > 0 && !seen[offset] {
+		seen[offset] = true
+		if offset >= len(d.data) {
+			return fmt.Errorf("pdfutil: xref offset %d out of range", offset)
+		}
+
+		var trailer Dict
+		if bytes.HasPrefix(d.data[offset:], []byte("xref")) {
+			trailer, err = d.readXrefTable(offset)
+		} else {
+			trailer, err = d.readXrefStream(offset)
+		}
+		if err != nil {
+			return err
+		}
+
+		if d.Trailer == nil {
+			d.Trailer = trailer
+		}
+
+		// Hybrid files keep additional entries in an xref stream
+		if stmOffset, ok := intValue(trailer["XRefStm"]); ok {
+			if !seen[stmOffset] {
+				seen[stmOffset] = true
+				if _, err := d.readXrefStream(stmOffset); err != nil {
+					return err
+				}
+			}
+		}
+
+		offset, _ = intValue(trailer["Prev"])
+	}
+
+	return nil
+}
+
+// readXrefTable reads a classic xref table and its trailer.
+func (d *Document) readXrefTable(offset int) (Dict, error) {
+	p := parser{data: d.data, pos: offset + len("xref")}
+	for {
+		p.skipSpace()
+		tok := p.readRegular()
+		if tok == "trailer" {
+			break
+		}
+
+		start, err1 := strconv.Atoi(tok)
+		p.skipSpace()
+		count, err2 := strconv.Atoi(p.readRegular())
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("pdfutil: invalid xref subsection at offset %d", p.pos)
+		}
+
+		for i := 0; i < count; i++ {
+			p.skipSpace()
+			off, err1 := strconv.Atoi(p.readRegular())
+			p.skipSpace()
+			_, err2 := strconv.Atoi(p.readRegular())
+			p.skipSpace()
+			kind := p.readRegular()
+			if err1 != nil || err2 != nil || (kind != "n" && kind != "f") {
+				return nil, fmt.Errorf("pdfutil: invalid xref entry at offset %d", p.pos)
+			}
+
+			// Newer sections are read first and take precedence
+			if _, ok := d.xref[start+i]; !ok {
+				if kind == "n" {
+					d.xref[start+i] = xrefEntry{offset: off}
+				} else {
+					d.xref[start+i] = xrefEntry{offset: -1}
+				}
+			}
+		}
+	}
+
+	obj, err := p.parseObject()
+	if err != nil {
+		return nil, err
+	}
+	trailer, ok := obj.(Dict)
+	if !ok {
+		return nil, errors.New("pdfutil: invalid trailer")
+	}
+	return trailer, nil
+}
+
+// readXrefStream reads a cross-reference stream at offset.
+func (d *Document) readXrefStream(offset int) (Dict, error) {
+	p := parser{data: d.data, pos: offset}
+	_, obj, err := p.parseIndirect()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, ok := obj.(*Stream)
+	if !ok || stream.Dict["Type"] != Name("XRef") {
+		return nil, fmt.Errorf("pdfutil: no xref at offset %d", offset)
+	}
+
+	data, err := Decode(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	widths, ok := intArray(stream.Dict["W"])
+	if !ok || len(widths) != 3 {
+		return nil, errors.New("pdfutil: invalid xref stream /W")
+	}
+	for _, w := range widths {
+		if w < 0 || w > 8 {
+			return nil, errors.New("pdfutil: invalid xref stream /W")
+		}
+	}
+	entrySize := widths[0] + widths[1] + widths[2]
+	if entrySize == 0 {
+		return nil, errors.New("pdfutil: invalid xref stream /W")
+	}
+
+	index, ok := intArray(stream.Dict["Index"])
+	if !ok {
+		size, _ := intValue(stream.Dict["Size"])
+		index = []int{0, size}
+	}
+
+	pos := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		for num := index[i]; num < index[i]+index[i+1]; num++ {
+			if pos+entrySize > len(data) {
+				return stream.Dict, nil
+			}
+			field := func(n int) int {
+				v := 0
+				for _, b := range data[pos : pos+n] {
+					v = v<<8 | int(b)
+				}
+				pos += n
+				return v
+			}
+
+			kind := 1
+			if widths[0] > 0 {
+				kind = field(widths[0])
+			}
+			f2 := field(widths[1])
+			f3 := field(widths[2])
+
+			if _, ok := d.xref[num]; ok {
+				continue
+			}
+			switch kind {
+			case 0:
+				d.xref[num] = xrefEntry{offset: -1}
+			case 1:
+				d.xref[num] = xrefEntry{offset: f2}
+			case 2:
+				d.xref[num] = xrefEntry{inStream: true, stream: f2, index: f3}
+			}
+		}
+	}
+
+	return stream.Dict, nil
+}
+
+// scanObjects rebuilds the xref by scanning for object headers and finds
+// the trailer (or catalog) to use.
+func (d *Document) scanObjects() error {
+	for _, m := range objectPattern.FindAllSubmatchIndex(d.data, -1) {
+		num, _ := strconv.Atoi(string(d.data[m[2]:m[3]]))
+		d.xref[num] = xrefEntry{offset: m[2]}
+	}
+	if len(d.xref) == 0 {
+		return errors.New("pdfutil: no objects found")
+	}
+
+	if i := bytes.LastIndex(d.data, []byte("trailer")); i >= 0 {
+		p := parser{data: d.data, pos: i + len("trailer")}
+		if obj, err := p.parseObject(); err == nil {
+			if trailer, ok := obj.(Dict); ok && trailer["Root"] != nil {
+				d.Trailer = trailer
+				return nil
+			}
+		}
+	}
+
+	// No usable trailer, look for the catalog or an xref stream dictionary
+	for num := range d.xref {
+		obj, err := d.Object(num)
+		if err != nil {
+			continue
+		}
+		if s, ok := obj.(*Stream); ok && s.Dict["Type"] == Name("XRef") && s.Dict["Root"] != nil {
+			d.Trailer = s.Dict
+			return nil
+		}
+		if dict, ok := obj.(Dict); ok && dict["Type"] == Name("Catalog") {
+			d.Trailer = Dict{"Root": Ref{Num: num}}
+		}
+	}
+	if d.Trailer == nil {
+		return errors.New("pdfutil: document catalog not found")
+	}
+	return nil
+}
+
+// Object returns indirect object num. Missing objects are null.
+func (d *Document) Object(num int) (Object, error) {
+	if obj, ok := d.objects[num]; ok {
+		return obj, nil
+	}
+
+	entry, ok := d.xref[num]
+	if !ok || (!entry.inStream && entry.offset < 0) {
+		return nil, nil
+	}
+
+	var obj Object
+	if entry.inStream {
+		stm, err := d.objectStream(entry.stream)
+		if err != nil {
+			return nil, err
+		}
+		if entry.index >= len(stm.offsets) {
+			return nil, fmt.Errorf("pdfutil: object %d not in object stream %d", num, entry.stream)
+		}
+		stm.parser.pos = stm.offsets[entry.index]
+		if obj, err = stm.parser.parseObject(); err != nil {
+			return nil, err
+		}
+	} else {
+		if entry.offset >= len(d.data) {
+			return nil, fmt.Errorf("pdfutil: object %d offset out of range", num)
+		}
+		p := parser{data: d.data, pos: entry.offset}
+		ref, parsed, err := p.parseIndirect()
+		if err != nil {
+			return nil, err
+		}
+		if ref.Num != num {
+			return nil, fmt.Errorf("pdfutil: expected object %d at offset %d, found %d", num, entry.offset, ref.Num)
+		}
+		obj = parsed
+	}
+
+	d.objects[num] = obj
+	return obj, nil
+}
+
+// Resolve follows obj if it is a reference.
+func (d *Document) Resolve(obj Object) (Object, error) {
+	for i := 0; i < 32; i++ {
+		ref, ok := obj.(Ref)
+		if !ok {
+			return obj, nil
+		}
+		var err error
+		if obj, err = d.Object(ref.Num); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("pdfutil: reference chain too long")
+}
+
+// objectStream returns the decoded object stream num.
+func (d *Document) objectStream(num int) (*objectStream, error) {
+	if stm, ok := d.objStms[num]; ok {
+		return stm, nil
+	}
+
+	// Object streams cannot themselves be compressed
+	if d.xref[num].inStream {
+		return nil, fmt.Errorf("pdfutil: object stream %d is inside an object stream", num)
+	}
+
+	obj, err := d.Object(num)
+	if err != nil {
+		return nil, err
+	}
+	stream, ok := obj.(*Stream)
+	if !ok {
+		return nil, fmt.Errorf("pdfutil: object %d is not an object stream", num)
+	}
+
+	data, err := Decode(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	n, _ := intValue(stream.Dict["N"])
+	first, _ := intValue(stream.Dict["First"])
+
+	stm := &objectStream{parser: parser{data: data}}
+	p := parser{data: data}
+	for i := 0; i < n; i++ {
+		p.skipSpace()
+		p.readRegular() // object number
+		p.skipSpace()
+		off, err := strconv.Atoi(p.readRegular())
+		if err != nil || first < 0 || off < 0 {
+			return nil, fmt.Errorf("pdfutil: invalid object stream %d header", num)
+		}
+		stm.offsets = append(stm.offsets, first+off)
+	}
+
+	d.objStms[num] = stm
+	return stm, nil
+}
+
+// Decode returns the decoded data of a stream. Only FlateDecode (with PNG
+// predictors) is supported.
+func Decode(s *Stream) ([]byte, error) {
+	var filter Name
+	switch f := s.Dict["Filter"].(type) {
+	case nil:
+		return s.Data, nil
+	case Name:
+		filter = f
+	case Array:
+		if len(f) == 0 {
+			return s.Data, nil
+		}
+		if len(f) > 1 {
+			return nil, errors.New("pdfutil: multiple stream filters are not supported")
+		}
+		filter, _ = f[0].(Name)
+	}
+	if filter != "FlateDecode" {
+		return nil, fmt.Errorf("pdfutil: unsupported stream filter %q", filter)
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(s.Data))
+	if err != nil {
+		return nil, fmt.Errorf("pdfutil: invalid flate stream: %w", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil && !(errors.Is(err, io.ErrUnexpectedEOF) && len(data) > 0) {
+		return nil, fmt.Errorf("pdfutil: invalid flate stream: %w", err)
+	}
+
+	params, _ := s.Dict["DecodeParms"].(Dict)
+	if arr, ok := s.Dict["DecodeParms"].(Array); ok && len(arr) > 0 {
+		params, _ = arr[0].(Dict)
+	}
+	return unpredict(data, params)
+}
+
+// unpredict reverses PNG predictors.
+func unpredict(data []byte, params Dict) ([]byte, error) {
+	param := func(name Name, def int) int {
+		if v, ok := intValue(params[name]); ok {
+			return v
+		}
+		return def
+	}
+
+	predictor := param("Predictor", 1)
+	if predictor < 10 {
+		if predictor != 1 {
+			return nil, fmt.Errorf("pdfutil: unsupported predictor %d", predictor)
+		}
+		return data, nil
+	}
+
+	colors := param("Colors", 1)
+	bpc := param("BitsPerComponent", 8)
+	columns := param("Columns", 1)
+	if colors < 1 || colors > 32 || bpc < 1 || bpc > 16 || columns < 1 {
+		return nil, errors.New("pdfutil: invalid predictor parameters")
+	}
+	if columns > 8*len(data) {
+		// Not even one complete row
+		return []byte{}, nil
+	}
+	bpp := (colors*bpc + 7) / 8
+	rowLen := (columns*colors*bpc + 7) / 8
+
+	out := make([]byte, 0, len(data))
+	prev := make([]byte, rowLen)
+	for pos := 0; pos+1+rowLen <= len(data); pos += 1 + rowLen {
+		kind := data[pos]
+		row := append([]byte(nil), data[pos+1:pos+1+rowLen]...)
+
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left = row[i-bpp]
+				upLeft = prev[i-bpp]
+			}
+			up := prev[i]
+
+			switch kind {
+			case 0:
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			default:
+				return nil, fmt.Errorf("pdfutil: invalid PNG predictor %d", kind)
+			}
+		}
+
+		out = append(out, row...)
+		prev = row
+	}
+
+	return out, nil
+}
+
+// paeth is the PNG Paeth predictor function.
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+// abs returns the absolute value of v.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// intValue returns the value of an integer object.
+func intValue(obj Object) (int, bool) {
+	n, ok := obj.(Number)
+	if !ok {
+		return 0, false
+	}
+	return n.Int()
+}
+
+// intArray converts an array of integers.
+func intArray(obj Object) ([]int, bool) {
+	arr, ok := obj.(Array)
+	if !ok {
+		return nil, false
+	}
+	ints := make([]int, len(arr))
+	for i, v := range arr {
+		var ok bool
+		if ints[i], ok = intValue(v); !ok {
+			return nil, false
+		}
+	}
+	return ints, true
+}
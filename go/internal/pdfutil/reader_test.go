@@ -0,0 +1,355 @@
+package pdfutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tablePDF builds a document with a classic xref table. objects[i] is the
+// body of object i+1; trailer holds extra trailer entries.
+func tablePDF(trailer string, objects ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, body := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<</Size %d/Root 1 0 R%s>>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+	return buf.Bytes()
+}
+
+// onePagePDF builds a single-page document whose page inherits its
+// MediaBox and draws content.
+func onePagePDF(content string) []byte {
+	return tablePDF("",
+		"<</Type/Catalog/Pages 2 0 R>>",
+		"<</Type/Pages/Kids[3 0 R]/Count 1/MediaBox[0 0 612 792]>>",
+		"<</Type/Page/Parent 2 0 R/Contents 4 0 R>>",
+		fmt.Sprintf("<</Length %d>>\nstream\n%s\nendstream", len(content), content),
+	)
+}
+
+// flate compresses data with zlib.
+func flate(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// xrefStreamPDF builds a PDF 1.5 document whose page lives in an object
+// stream and whose xref is a compressed stream with a PNG Up predictor.
+func xrefStreamPDF() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	offsets := map[int]int{}
+
+	writeObject := func(num int, obj Object) {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", num)
+		Write(&buf, obj)
+		buf.WriteString("\nendobj\n")
+	}
+
+	writeObject(1, Dict{"Type": Name("Catalog"), "Pages": Ref{Num: 2}})
+	writeObject(2, Dict{
+		"Type":     Name("Pages"),
+		"Kids":     Array{Ref{Num: 3}},
+		"Count":    Number("1"),
+		"MediaBox": Array{Number("0"), Number("0"), Number("200"), Number("100")},
+	})
+
+	header := "3 0 "
+	body := "<</Type/Page/Parent 2 0 R/Rotate 90>>"
+	writeObject(4, &Stream{
+		Dict: Dict{
+			"Type":   Name("ObjStm"),
+			"N":      Number("1"),
+			"First":  Number(fmt.Sprint(len(header))),
+			"Filter": Name("FlateDecode"),
+		},
+		Data: flate([]byte(header + body)),
+	})
+
+	// Entries for objects 0-5 with /W [1 2 1]
+	offsets[5] = buf.Len()
+	rows := [][]byte{
+		{0, 0, 0, 255},
+		{1, byte(offsets[1] >> 8), byte(offsets[1]), 0},
+		{1, byte(offsets[2] >> 8), byte(offsets[2]), 0},
+		{2, 0, 4, 0},
+		{1, byte(offsets[4] >> 8), byte(offsets[4]), 0},
+		{1, byte(offsets[5] >> 8), byte(offsets[5]), 0},
+	}
+	var encoded []byte
+	prev := make([]byte, 4)
+	for _, row := range rows {
+		encoded = append(encoded, 2)
+		for i := range row {
+			encoded = append(encoded, row[i]-prev[i])
+		}
+		prev = row
+	}
+	writeObject(5, &Stream{
+		Dict: Dict{
+			"Type":        Name("XRef"),
+			"Size":        Number("6"),
+			"W":           Array{Number("1"), Number("2"), Number("1")},
+			"Root":        Ref{Num: 1},
+			"Filter":      Name("FlateDecode"),
+			"DecodeParms": Dict{"Predictor": Number("12"), "Columns": Number("4")},
+		},
+		Data: flate(encoded),
+	})
+
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", offsets[5])
+	return buf.Bytes()
+}
+
+// incrementalPDF appends an update to onePagePDF that rotates the page.
+func incrementalPDF() []byte {
+	base := onePagePDF("BT ET")
+	prev := bytes.LastIndex(base, []byte("xref"))
+
+	var buf bytes.Buffer
+	buf.Write(base)
+	offset := buf.Len()
+	buf.WriteString("3 0 obj\n<</Type/Page/Parent 2 0 R/Contents 4 0 R/Rotate 180>>\nendobj\n")
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n3 1\n%010d 00000 n \ntrailer\n<</Size 5/Root 1 0 R/Prev %d>>\nstartxref\n%d\n%%%%EOF\n", offset, prev, xref)
+	return buf.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	valid := onePagePDF("BT ET")
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantErr    error
+		wantAnyErr bool
+		wantPages  int
+		wantRotate Object
+		wantMedia  Object
+	}{
+		{
+			name:      "xref table",
+			data:      valid,
+			wantPages: 1,
+			wantMedia: Array{Number("0"), Number("0"), Number("612"), Number("792")},
+		},
+		{
+			name:       "xref stream and object stream",
+			data:       xrefStreamPDF(),
+			wantPages:  1,
+			wantRotate: Number("90"),
+			wantMedia:  Array{Number("0"), Number("0"), Number("200"), Number("100")},
+		},
+		{
+			name:       "incremental update",
+			data:       incrementalPDF(),
+			wantPages:  1,
+			wantRotate: Number("180"),
+		},
+		{
+			name:      "leading whitespace",
+			data:      append([]byte("\r\n"), valid...),
+			wantPages: 1,
+		},
+		{
+			name:      "stale xref offsets",
+			data:      bytes.Replace(valid, []byte("%PDF-1.4\n"), []byte("%PDF-1.4\n% padding that shifts every object\n"), 1),
+			wantPages: 1,
+		},
+		{
+			name:      "missing xref and trailer",
+			data:      valid[:bytes.Index(valid, []byte("xref"))],
+			wantPages: 1,
+		},
+		{
+			name:      "startxref out of range",
+			data:      bytes.Replace(valid, []byte("startxref\n"), []byte("startxref\n99999"), 1),
+			wantPages: 1,
+		},
+		{name: "not a PDF", data: []byte("<html></html>"), wantErr: ErrNotPDF},
+		{name: "empty", data: nil, wantErr: ErrNotPDF},
+		{name: "encrypted", data: tablePDF("/Encrypt 2 0 R", "<</Type/Catalog/Pages 3 0 R>>", "<</Filter/Standard>>"), wantErr: ErrEncrypted},
+		{name: "no objects", data: []byte("%PDF-1.4\n%%EOF\n"), wantAnyErr: true},
+		{name: "no catalog", data: []byte("%PDF-1.4\n1 0 obj\n<</Type/Font>>\nendobj\n%%EOF\n"), wantAnyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse(tt.data)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil {
+					t.Fatal("Parse succeeded, want error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("Parse error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			_, pages, err := d.Pages()
+			if err != nil {
+				t.Fatalf("Pages: %v", err)
+			}
+			if len(pages) != tt.wantPages {
+				t.Fatalf("got %d pages, want %d", len(pages), tt.wantPages)
+			}
+			if tt.wantRotate != nil && !reflect.DeepEqual(pages[0]["Rotate"], tt.wantRotate) {
+				t.Errorf("Rotate = %v, want %v", pages[0]["Rotate"], tt.wantRotate)
+			}
+			if tt.wantMedia != nil && !reflect.DeepEqual(pages[0]["MediaBox"], tt.wantMedia) {
+				t.Errorf("MediaBox = %v, want %v", pages[0]["MediaBox"], tt.wantMedia)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	d, err := Parse(tablePDF("",
+		"<</Type/Catalog/Pages 2 0 R>>",
+		"3 0 R",
+		"<</Type/Pages/Kids[]/Count 0>>",
+		"5 0 R",
+		"4 0 R",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		obj     Object
+		want    Object
+		wantErr bool
+	}{
+		{name: "direct object", obj: Number("7"), want: Number("7")},
+		{name: "reference", obj: Ref{Num: 3}, want: Dict{"Type": Name("Pages"), "Kids": Array{}, "Count": Number("0")}},
+		{name: "reference to reference", obj: Ref{Num: 2}, want: Dict{"Type": Name("Pages"), "Kids": Array{}, "Count": Number("0")}},
+		{name: "missing object", obj: Ref{Num: 99}, want: nil},
+		{name: "free object", obj: Ref{Num: 0}, want: nil},
+		{name: "reference cycle", obj: Ref{Num: 4}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.Resolve(tt.obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Resolve(%v) = %v, want error", tt.obj, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve(%v): %v", tt.obj, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve(%v) = %#v, want %#v", tt.obj, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectAtWrongOffset(t *testing.T) {
+	d, err := Parse(onePagePDF("BT ET"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Point object 3's xref entry at object 2
+	d.xref[3] = d.xref[2]
+
+	if _, err := d.Object(3); err == nil || !strings.Contains(err.Error(), "expected object 3") {
+		t.Errorf("Object(3) error = %v, want a mismatch error", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	up := func(columns string) Dict {
+		return Dict{"Predictor": Number("12"), "Columns": Number(columns)}
+	}
+
+	tests := []struct {
+		name    string
+		stream  *Stream
+		want    string
+		wantErr bool
+	}{
+		{name: "unfiltered", stream: &Stream{Data: []byte("raw")}, want: "raw"},
+		{name: "flate", stream: &Stream{Dict: Dict{"Filter": Name("FlateDecode")}, Data: flate([]byte("hello"))}, want: "hello"},
+		{name: "filter array", stream: &Stream{Dict: Dict{"Filter": Array{Name("FlateDecode")}}, Data: flate([]byte("hello"))}, want: "hello"},
+		{
+			name:   "PNG up predictor",
+			stream: &Stream{Dict: Dict{"Filter": Name("FlateDecode"), "DecodeParms": up("2")}, Data: flate([]byte{2, 1, 2, 2, 1, 1})},
+			want:   "\x01\x02\x02\x03",
+		},
+		{name: "unsupported filter", stream: &Stream{Dict: Dict{"Filter": Name("DCTDecode")}}, wantErr: true},
+		{name: "multiple filters", stream: &Stream{Dict: Dict{"Filter": Array{Name("FlateDecode"), Name("ASCIIHexDecode")}}}, wantErr: true},
+		{name: "invalid flate data", stream: &Stream{Dict: Dict{"Filter": Name("FlateDecode")}, Data: []byte("not zlib")}, wantErr: true},
+		{name: "negative columns", stream: &Stream{Dict: Dict{"Filter": Name("FlateDecode"), "DecodeParms": up("-4")}, Data: flate([]byte{2, 1})}, wantErr: true},
+		{name: "invalid row predictor", stream: &Stream{Dict: Dict{"Filter": Name("FlateDecode"), "DecodeParms": up("1")}, Data: flate([]byte{9, 1})}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.stream)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Decode = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Decode = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	valid := onePagePDF("BT ET")
+	f.Add(valid)
+	f.Add(xrefStreamPDF())
+	f.Add(incrementalPDF())
+	f.Add(valid[:len(valid)/2])
+	f.Add(valid[:bytes.Index(valid, []byte("xref"))])
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n<</Type/Catalog/Pages 1 0 R>>\nendobj\n"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n<</Length 3>>\nstream\nab"))
+	f.Add([]byte("%PDF-"))
+	f.Add([]byte("%PDF-1.5\n1 0 obj\n<</Type/XRef/W[1 -2 1]/Size 2/Root 2 0 R/Length 8>>\nstream\n\x01\x00\x00\x00\x01\x00\x00\x00\nendstream\nendobj\nstartxref\n9\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := Parse(data)
+		if err != nil {
+			return
+		}
+		for num := range d.xref {
+			d.Object(num)
+		}
+		if _, _, err := d.Pages(); err == nil {
+			Merge(data)
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package screencraft
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	jobEventsEndpoint = "/jobs/%s/events"
+)
+
+// JobEventType is the kind of a job progress event.
+type JobEventType string
+
+const (
+	// JobEventQueued is sent while the job waits for a browser.
+	JobEventQueued JobEventType = "queued"
+	// JobEventNavigating is sent while the page loads.
+	JobEventNavigating JobEventType = "navigating"
+	// JobEventWaiting is sent while waiting for selectors, delays or network
+	// idle.
+	JobEventWaiting JobEventType = "waiting"
+	// JobEventCapturing is sent while the screenshot or PDF is rendered.
+	JobEventCapturing JobEventType = "capturing"
+	// JobEventUploading is sent while the result is stored.
+	JobEventUploading JobEventType = "uploading"
+	// JobEventDone is sent when the job completed. It is the last event.
+	JobEventDone JobEventType = "done"
+	// JobEventFailed is sent when the job failed. It is the last event.
+	JobEventFailed JobEventType = "failed"
+)
+
+// JobEvent is a progress event of an async job.
+type JobEvent struct {
+	// ID identifies the event within the stream.
+	ID string `json:"id,omitempty"`
+	// Type is the stage the job reached.
+	Type JobEventType `json:"type"`
+	// JobID is the job the event belongs to.
+	JobID string `json:"jobId"`
+	// Progress is the estimated overall progress (0-1), if known.
+	Progress float64 `json:"progress,omitempty"`
+	// Message is a human-readable description of the stage.
+	Message string `json:"message,omitempty"`
+	// ResultURL is where the result can be downloaded, on JobEventDone.
+	ResultURL string `json:"resultUrl,omitempty"`
+	// Error describes the failure, on JobEventFailed.
+	Error *APIErrorDetails `json:"error,omitempty"`
+	// Time is when the event occurred.
+	Time time.Time `json:"time"`
+
+	// Err is set on the last value sent if the stream broke off before the
+	// job finished. The other fields are empty then.
+	Err error `json:"-"`
+}
+
+// Final reports whether e is the last event of its job.
+func (e JobEvent) Final() bool {
+	return e.Type == JobEventDone || e.Type == JobEventFailed
+}
+
+// StreamJobEvents subscribes to the progress events of an async job over
+// server-sent events. The channel is closed after the job's final event,
+// when ctx is canceled, or after a value with Err set if the stream cannot
+// be resumed. Dropped connections are resumed where they left off, up to
+// the client's retry limit.
+//
+// Example:
+//
+//	events, err := client.StreamJobEvents(ctx, jobID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for event := range events {
+//	    if event.Err != nil {
+//	        log.Fatal(event.Err)
+//	    }
+//	    fmt.Printf("%s %.0f%%\n", event.Type, event.Progress*100)
+//	}
+func (c *Client) StreamJobEvents(ctx context.Context, jobID string) (<-chan JobEvent, error) {
+	if jobID == "" {
+		return nil, NewValidationError("jobId", "job ID is required", "required").Error
+	}
+
+	endpoint := fmt.Sprintf(jobEventsEndpoint, url.PathEscape(jobID))
+	resp, err := c.openJobEvents(ctx, endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan JobEvent)
+	go func() {
+		defer close(events)
+
+		maxRetries, _ := c.callSettings(ctx)
+		var lastID string
+		failures := 0
+		for {
+			final, n, err := c.readJobEvents(ctx, resp.Body, jobID, &lastID, events)
+			resp.Body.Close()
+			if final || ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+
+			// Reconnect, counting only attempts that made no progress
+			if n > 0 {
+				failures = 0
+			}
+			for {
+				failures++
+				if failures > maxRetries {
+					sendJobEvent(ctx, events, JobEvent{JobID: jobID, Err: fmt.Errorf("screencraft: job event stream broke off: %w", err)})
+					return
+				}
+				c.logf("Reconnecting to job %s events after event %q", jobID, lastID)
+				resp, err = c.openJobEvents(ctx, endpoint, lastID)
+				if err == nil {
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if serr := c.sleep(ctx, c.activeRetryPolicy().Backoff(failures, err)); serr != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// openJobEvents connects to a job's event stream, resuming after lastID if
+// set.
+func (c *Client) openJobEvents(ctx context.Context, endpoint, lastID string) (*http.Response, error) {
+	callOpts := []CallOption{withHeader("Accept", "text/event-stream")}
+	if lastID != "" {
+		callOpts = append(callOpts, withHeader("Last-Event-ID", lastID))
+	}
+
+	resp, _, err := c.doRequest(withCallOptions(ctx, callOpts), http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		resp.Body.Close()
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("unexpected content type %q for job events", ct),
+		}
+	}
+	return resp, nil
+}
+
+// readJobEvents reads server-sent events from r and sends them on events
+// until the job's final event, the end of the stream or an error. It reports
+// whether the final event was sent and how many events were sent.
+func (c *Client) readJobEvents(ctx context.Context, r io.Reader, jobID string, lastID *string, events chan<- JobEvent) (bool, int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	sent := 0
+	var id, name string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				id = value
+			case "event":
+				name = value
+			case "data":
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(value)
+			}
+			continue
+		}
+
+		// A blank line dispatches the event
+		if data.Len() == 0 {
+			id, name = "", ""
+			continue
+		}
+		var event JobEvent
+		if err := c.decodeJSON([]byte(data.String()), &event); err != nil {
+			return false, sent, fmt.Errorf("screencraft: failed to parse job event: %w", err)
+		}
+		if event.Type == "" {
+			event.Type = JobEventType(name)
+		}
+		if event.ID == "" {
+			event.ID = id
+		}
+		if event.JobID == "" {
+			event.JobID = jobID
+		}
+		if id != "" {
+			*lastID = id
+		}
+		id, name = "", ""
+		data.Reset()
+
+		if !sendJobEvent(ctx, events, event) {
+			return false, sent, ctx.Err()
+		}
+		sent++
+		if event.Final() {
+			return true, sent, nil
+		}
+	}
+	return false, sent, scanner.Err()
+}
+
+// sendJobEvent sends event unless ctx is done first.
+func sendJobEvent(ctx context.Context, events chan<- JobEvent, event JobEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,226 @@
+package screencraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrJobNotFound is returned by JobStore.Get when no job has the given ID.
+var ErrJobNotFound = errors.New("screencraft: job not found")
+
+// JobStatus is the lifecycle state of a tracked async job.
+type JobStatus string
+
+const (
+	// JobSubmitting is a job that was recorded but not yet accepted by the
+	// API, e.g. because the process stopped mid-request.
+	JobSubmitting JobStatus = "submitting"
+	// JobPending is a job accepted by the API whose webhook has not arrived.
+	JobPending JobStatus = "pending"
+	// JobCompleted is a job whose webhook reported success.
+	JobCompleted JobStatus = "completed"
+	// JobFailed is a job that was rejected or whose webhook reported failure.
+	JobFailed JobStatus = "failed"
+)
+
+// Done reports whether s is a final status.
+func (s JobStatus) Done() bool {
+	return s == JobCompleted || s == JobFailed
+}
+
+// JobRecord is an async job tracked in a JobStore.
+type JobRecord struct {
+	// Key identifies the record. It is also sent as the submission's
+	// Idempotency-Key, so a resubmitted job is only created once.
+	Key string `json:"key"`
+	// JobID is the API job ID, once the API has accepted the job.
+	JobID string `json:"jobId,omitempty"`
+	// Kind is "screenshot" or "pdf".
+	Kind string `json:"kind"`
+	// OptionsHash is the fingerprint of the capture options (see OptionsFingerprint).
+	OptionsHash string `json:"optionsHash"`
+	// Options are the JSON-encoded capture options, used to resubmit the job.
+	Options json.RawMessage `json:"options"`
+	// Status is the state of the job.
+	Status JobStatus `json:"status"`
+	// Error describes why the job failed, if it did.
+	Error string `json:"error,omitempty"`
+	// SubmittedAt is when the job was first submitted.
+	SubmittedAt time.Time `json:"submittedAt"`
+	// UpdatedAt is when the record last changed.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// JobStore persists async jobs so that a process restart does not lose track
+// of jobs in flight. See the jobstore package for a file-backed
+// implementation.
+//
+// Implementations must be safe for concurrent use.
+type JobStore interface {
+	// Save inserts or replaces the record with job.Key.
+	Save(job *JobRecord) error
+
+	// Get returns the record of the job with the given API job ID, or
+	// ErrJobNotFound.
+	Get(jobID string) (*JobRecord, error)
+
+	// Pending returns the records whose status is not final, oldest first.
+	Pending() ([]*JobRecord, error)
+}
+
+// WithJobStore records every ScreenshotAsync and PDFAsync job in store. Set
+// the same store as WebhookHandler.Jobs to mark jobs done when their
+// webhooks arrive, and call ResumePendingJobs on startup.
+//
+// The store holds the full capture options, including webhook secrets.
+//
+// Example:
+//
+//	store, err := jobstore.Open("/var/lib/myapp/jobs")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := screencraft.New(apiKey, screencraft.WithJobStore(store))
+func WithJobStore(store JobStore) Option {
+	return func(c *Client) {
+		c.jobs = store
+	}
+}
+
+// ResumePendingJobs picks up the async jobs left in store by a previous run.
+// Jobs that were recorded but may not have reached the API are resubmitted
+// under their original idempotency key, so the API creates each job at most
+// once. It returns the jobs still awaiting their webhooks.
+//
+// Example:
+//
+//	pending, err := client.ResumePendingJobs(ctx, store)
+//	if err != nil {
+//	    log.Print(err)
+//	}
+//	log.Printf("%d jobs in flight", len(pending))
+func (c *Client) ResumePendingJobs(ctx context.Context, store JobStore) ([]*JobRecord, error) {
+	jobs, err := store.Pending()
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to list pending jobs: %w", err)
+	}
+
+	var pending []*JobRecord
+	var errs []error
+	for _, job := range jobs {
+		if job.Status == JobSubmitting {
+			if _, err := c.resubmitJob(ctx, store, job); err != nil {
+				if ctx.Err() != nil {
+					return pending, ctx.Err()
+				}
+				errs = append(errs, fmt.Errorf("job %s: %w", job.Key, err))
+			}
+		}
+		if !job.Status.Done() {
+			pending = append(pending, job)
+		}
+	}
+	return pending, errors.Join(errs...)
+}
+
+// resubmitJob submits a recorded job again.
+func (c *Client) resubmitJob(ctx context.Context, store JobStore, job *JobRecord) (string, error) {
+	switch job.Kind {
+	case "screenshot":
+		var opts ScreenshotOptions
+		if err := json.Unmarshal(job.Options, &opts); err != nil {
+			return "", fmt.Errorf("screencraft: failed to decode job options: %w", err)
+		}
+		return c.submitJob(ctx, store, job, func(ctx context.Context) (string, error) {
+			return c.screenshotAsync(ctx, &opts)
+		})
+	case "pdf":
+		var opts PDFOptions
+		if err := json.Unmarshal(job.Options, &opts); err != nil {
+			return "", fmt.Errorf("screencraft: failed to decode job options: %w", err)
+		}
+		return c.submitJob(ctx, store, job, func(ctx context.Context) (string, error) {
+			return c.pdfAsync(ctx, &opts)
+		})
+	default:
+		return "", fmt.Errorf("screencraft: unknown job kind %q", job.Kind)
+	}
+}
+
+// trackJob records a new job in the client's job store and submits it.
+func (c *Client) trackJob(ctx context.Context, kind string, opts interface{}, submit func(ctx context.Context) (string, error)) (string, error) {
+	options, err := json.Marshal(opts)
+	if err != nil {
+		return "", fmt.Errorf("screencraft: failed to encode job options: %w", err)
+	}
+
+	now := c.now().UTC()
+	job := &JobRecord{
+		Key:         newIdempotencyKey(),
+		Kind:        kind,
+		OptionsHash: OptionsFingerprint(opts),
+		Options:     options,
+		Status:      JobSubmitting,
+		SubmittedAt: now,
+		UpdatedAt:   now,
+	}
+	if err := c.jobs.Save(job); err != nil {
+		return "", fmt.Errorf("screencraft: failed to record job: %w", err)
+	}
+
+	return c.submitJob(ctx, c.jobs, job, submit)
+}
+
+// submitJob submits a recorded job and stores the outcome. Jobs whose
+// submission failed ambiguously, e.g. with a network error, stay in
+// JobSubmitting so ResumePendingJobs can retry them.
+func (c *Client) submitJob(ctx context.Context, store JobStore, job *JobRecord, submit func(ctx context.Context) (string, error)) (string, error) {
+	jobID, err := submit(withCallOptions(ctx, []CallOption{withIdempotencyKey(job.Key)}))
+	if err != nil {
+		if ctx.Err() != nil || IsRetryable(err) {
+			return "", err
+		}
+		job.Status = JobFailed
+		job.Error = err.Error()
+	} else {
+		job.JobID = jobID
+		job.Status = JobPending
+	}
+	job.UpdatedAt = c.now().UTC()
+
+	if saveErr := store.Save(job); saveErr != nil {
+		if err != nil {
+			return "", err
+		}
+		return jobID, fmt.Errorf("screencraft: job %s submitted but not recorded: %w", jobID, saveErr)
+	}
+	return jobID, err
+}
+
+// finishJob marks the job of a webhook event as completed or failed.
+func finishJob(store JobStore, event *WebhookEvent) error {
+	if event.JobID == "" {
+		return nil
+	}
+
+	job, err := store.Get(event.JobID)
+	if errors.Is(err, ErrJobNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	job.Status = JobCompleted
+	if event.Status != string(JobCompleted) {
+		job.Status = JobFailed
+		if event.Error != nil {
+			job.Error = event.Error.Message
+		}
+	}
+	job.UpdatedAt = time.Now().UTC()
+	return store.Save(job)
+}
@@ -0,0 +1,208 @@
+// Package jobstore provides a file-backed store for ScreenCraft async jobs.
+//
+// Every ScreenshotAsync and PDFAsync job is written to disk before it is
+// submitted and updated when the API accepts it and when its webhook
+// arrives, so jobs in flight during a crash or restart are picked up again
+// on startup.
+//
+// Basic usage:
+//
+//	store, err := jobstore.Open("/var/lib/myapp/jobs")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	client := screencraft.New(apiKey, screencraft.WithJobStore(store))
+//
+//	handler := screencraft.NewWebhookHandler(secret, process)
+//	handler.Jobs = store
+//	http.Handle("/webhook", handler)
+//
+//	// Resubmit jobs interrupted by a previous run
+//	pending, err := client.ResumePendingJobs(ctx, store)
+package jobstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+)
+
+const (
+	pendingDir = "pending"
+	doneDir    = "done"
+)
+
+// Store is a file-backed job store. It implements screencraft.JobStore.
+//
+// Each job is stored as one JSON file, in a pending or done directory
+// depending on its status. A Store is safe for concurrent use within one
+// process.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var _ screencraft.JobStore = (*Store)(nil)
+
+// Open opens or creates a job store in the given directory.
+func Open(dir string) (*Store, error) {
+	for _, sub := range []string{pendingDir, doneDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("jobstore: %w", err)
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Save inserts or replaces the record with job.Key.
+func (s *Store) Save(job *screencraft.JobRecord) error {
+	if job == nil || job.Key == "" {
+		return errors.New("jobstore: job key is required")
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("jobstore: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := fileName(job.Key)
+	target, stale := pendingDir, doneDir
+	if job.Status.Done() {
+		target, stale = doneDir, pendingDir
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.dir, target, name), data); err != nil {
+		return fmt.Errorf("jobstore: %w", err)
+	}
+	if err := os.Remove(filepath.Join(s.dir, stale, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("jobstore: %w", err)
+	}
+	return nil
+}
+
+// Get returns the record of the job with the given API job ID, or
+// screencraft.ErrJobNotFound.
+func (s *Store) Get(jobID string) (*screencraft.JobRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range []string{pendingDir, doneDir} {
+		jobs, err := s.read(sub)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			if job.JobID == jobID {
+				return job, nil
+			}
+		}
+	}
+	return nil, screencraft.ErrJobNotFound
+}
+
+// Pending returns the jobs that are not completed or failed, oldest first.
+func (s *Store) Pending() ([]*screencraft.JobRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs, err := s.read(pendingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].SubmittedAt.Before(jobs[j].SubmittedAt)
+	})
+	return jobs, nil
+}
+
+// Prune removes completed and failed jobs last updated before the given age.
+func (s *Store) Prune(olderThan time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs, err := s.read(doneDir)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	for _, job := range jobs {
+		if job.UpdatedAt.Before(cutoff) {
+			path := filepath.Join(s.dir, doneDir, fileName(job.Key))
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("jobstore: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// read returns the jobs in the subdirectory sub. s.mu must be held.
+func (s *Store) read(sub string) ([]*screencraft.JobRecord, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, sub))
+	if err != nil {
+		return nil, fmt.Errorf("jobstore: %w", err)
+	}
+
+	var jobs []*screencraft.JobRecord
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dir, sub, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("jobstore: %w", err)
+		}
+
+		var job screencraft.JobRecord
+		if err := json.Unmarshal(data, &job); err != nil {
+			return nil, fmt.Errorf("jobstore: %s: %w", entry.Name(), err)
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+// fileName returns a filesystem-safe file name for a job key.
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// writeFileAtomic writes data to path via a temporary file and rename, so
+// readers never observe partial files.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,160 @@
+package screencraft
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// keyRejectedCooldown is how long an API key rejected with 401 is skipped
+// before the client tries it again.
+const keyRejectedCooldown = 5 * time.Minute
+
+// KeySelection is the strategy for spreading requests over the API keys set
+// with WithAPIKeys.
+type KeySelection int
+
+const (
+	// KeyRoundRobin uses the keys in turn.
+	KeyRoundRobin KeySelection = iota
+	// KeyLeastLoaded uses the key with the most requests left in its rate
+	// limit window, as reported by X-RateLimit-Remaining. Keys that have not
+	// been used yet are preferred.
+	KeyLeastLoaded
+)
+
+// WithAPIKeys spreads requests over several API keys, e.g. of different
+// projects, to raise the combined rate limit. Keys are used in turn (see
+// WithKeySelection). When the API rejects a key with 429 or 401, the request
+// is retried at once with another key, and the rejected key is skipped until
+// its rate limit resets or, for 401, for a cooldown period.
+//
+// The first key replaces the key passed to New. SetAPIKey and RotateAPIKey
+// do not affect the pool.
+//
+// Example:
+//
+//	client := screencraft.New("", screencraft.WithAPIKeys(key1, key2, key3))
+func WithAPIKeys(keys ...string) Option {
+	return func(c *Client) {
+		if len(keys) == 0 {
+			c.apiKeys = nil
+			return
+		}
+		c.apiKey = keys[0]
+		c.apiKeys = newAPIKeyPool(keys)
+	}
+}
+
+// WithKeySelection sets how requests are spread over the keys set with
+// WithAPIKeys. The default is KeyRoundRobin.
+//
+// Example:
+//
+//	client := screencraft.New("",
+//	    screencraft.WithAPIKeys(key1, key2),
+//	    screencraft.WithKeySelection(screencraft.KeyLeastLoaded),
+//	)
+func WithKeySelection(selection KeySelection) Option {
+	return func(c *Client) {
+		c.keySelection = selection
+	}
+}
+
+// apiKeyPool tracks the load and health of a list of API keys.
+type apiKeyPool struct {
+	mu        sync.Mutex
+	keys      []string
+	next      int
+	remaining []int
+	reset     []time.Time
+	downUntil []time.Time
+}
+
+// newAPIKeyPool creates a pool with all keys available.
+func newAPIKeyPool(keys []string) *apiKeyPool {
+	return &apiKeyPool{
+		keys:      keys,
+		remaining: make([]int, len(keys)),
+		reset:     make([]time.Time, len(keys)),
+		downUntil: make([]time.Time, len(keys)),
+	}
+}
+
+// pick returns the key for the next attempt and its index, chosen with the
+// given strategy. If all keys are unavailable, it returns the one that
+// recovers first.
+func (p *apiKeyPool) pick(selection KeySelection, now time.Time) (string, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chosen, best := -1, -1
+	soonest := 0
+	for n := 0; n < len(p.keys); n++ {
+		i := (p.next + n) % len(p.keys)
+		if now.Before(p.downUntil[i]) {
+			if p.downUntil[i].Before(p.downUntil[soonest]) {
+				soonest = i
+			}
+			continue
+		}
+		if selection != KeyLeastLoaded {
+			chosen = i
+			break
+		}
+
+		// Keys without a current rate limit window have their full limit
+		load := math.MaxInt
+		if p.reset[i].After(now) {
+			load = p.remaining[i]
+		}
+		if load > best {
+			chosen, best = i, load
+		}
+	}
+	if chosen < 0 {
+		chosen = soonest
+	}
+
+	p.next = (chosen + 1) % len(p.keys)
+	return p.keys[chosen], chosen
+}
+
+// report records the rate limit state of the key at index i after a
+// response, and takes the key out of rotation if the API rejected it.
+func (p *apiKeyPool) report(i, statusCode int, rateLimit *RateLimitInfo, retryAfter time.Duration, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if rateLimit != nil {
+		p.remaining[i] = rateLimit.Remaining
+		p.reset[i] = rateLimit.Reset
+	}
+
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		switch {
+		case p.reset[i].After(now):
+			p.downUntil[i] = p.reset[i]
+		case retryAfter > 0:
+			p.downUntil[i] = now.Add(retryAfter)
+		default:
+			p.downUntil[i] = now.Add(failoverCooldown)
+		}
+	case http.StatusUnauthorized:
+		p.downUntil[i] = now.Add(keyRejectedCooldown)
+	}
+}
+
+// reportAPIKey records the outcome of an attempt made with the pooled key at
+// index i. It reports whether the key was rejected, so the request should be
+// retried with another key.
+func (c *Client) reportAPIKey(i, statusCode int, rateLimit *RateLimitInfo, err error) bool {
+	if c.apiKeys == nil || i < 0 {
+		return false
+	}
+
+	c.apiKeys.report(i, statusCode, rateLimit, GetRetryAfter(err), c.now())
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusUnauthorized
+}
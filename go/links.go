@@ -0,0 +1,187 @@
+package screencraft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	linksEndpoint = "/links"
+)
+
+// LinksOptions represents options for extracting links from a page.
+type LinksOptions struct {
+	// AcceptCookies automatically accepts cookie consent banners.
+	AcceptCookies bool `json:"acceptCookies,omitempty"`
+	// Delay is the time to wait after page load before extraction (in milliseconds).
+	Delay int `json:"delay,omitempty"`
+	// WaitUntil specifies the page load event to wait for.
+	WaitUntil WaitUntil `json:"waitUntil,omitempty"`
+	// WaitForSelector waits for a specific CSS selector to appear.
+	WaitForSelector string `json:"waitForSelector,omitempty"`
+	// Cookies are cookies to set before navigation.
+	Cookies []Cookie `json:"cookies,omitempty"`
+	// Headers are custom HTTP headers to send.
+	Headers []Header `json:"headers,omitempty"`
+	// UserAgent sets a custom user agent string.
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+// Link represents an anchor found in the rendered page.
+type Link struct {
+	// Href is the absolute URL the anchor points to.
+	Href string `json:"href"`
+	// Text is the visible anchor text.
+	Text string `json:"text"`
+	// Rel is the anchor's rel attribute.
+	Rel string `json:"rel,omitempty"`
+	// Internal is true if the link points to the same host as the page.
+	Internal bool `json:"-"`
+}
+
+// LinksResult represents the result of a link extraction.
+type LinksResult struct {
+	RetryInfo
+
+	// URL is the page URL the links were extracted from.
+	URL string
+	// Links contains all anchors in document order.
+	Links []Link
+}
+
+// Internal returns the links pointing to the same host as the page.
+func (r *LinksResult) Internal() []Link {
+	var links []Link
+	for _, l := range r.Links {
+		if l.Internal {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// External returns the links pointing to other hosts.
+func (r *LinksResult) External() []Link {
+	var links []Link
+	for _, l := range r.Links {
+		if !l.Internal {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// linksResponse is the API response for a link extraction request.
+type linksResponse struct {
+	APIResponse
+	Data *struct {
+		URL   string `json:"url,omitempty"`
+		Links []Link `json:"links"`
+	} `json:"data,omitempty"`
+}
+
+// Links extracts all anchors from the rendered DOM of the specified URL.
+//
+// Links are classified as internal or external relative to the final page
+// URL, which allows crawl-and-capture workflows without a separate scraper.
+//
+// Example:
+//
+//	result, err := client.Links(ctx, "https://example.com", nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, link := range result.Internal() {
+//	    fmt.Println(link.Href)
+//	}
+func (c *Client) Links(ctx context.Context, pageURL string, opts *LinksOptions) (*LinksResult, error) {
+	if pageURL == "" {
+		return nil, ErrMissingURL
+	}
+
+	reqBody := map[string]interface{}{
+		"url": pageURL,
+	}
+	if opts != nil {
+		if opts.AcceptCookies {
+			reqBody["acceptCookies"] = true
+		}
+		if opts.Delay > 0 {
+			reqBody["delay"] = opts.Delay
+		}
+		if opts.WaitUntil != "" {
+			reqBody["waitUntil"] = opts.WaitUntil
+		}
+		if opts.WaitForSelector != "" {
+			reqBody["waitForSelector"] = opts.WaitForSelector
+		}
+		if len(opts.Cookies) > 0 {
+			reqBody["cookies"] = opts.Cookies
+		}
+		if len(opts.Headers) > 0 {
+			reqBody["headers"] = opts.Headers
+		}
+		if opts.UserAgent != "" {
+			reqBody["userAgent"] = opts.UserAgent
+		}
+	}
+
+	resp, info, err := c.doRequest(ctx, http.MethodPost, linksEndpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var linksResp linksResponse
+	if err := c.decodeJSON(body, &linksResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !linksResp.Success || linksResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    linksResp.Message,
+		}
+	}
+
+	// Classify relative to the final URL in case of redirects
+	finalURL := pageURL
+	if linksResp.Data.URL != "" {
+		finalURL = linksResp.Data.URL
+	}
+
+	base, _ := url.Parse(finalURL)
+	links := linksResp.Data.Links
+	for i := range links {
+		links[i].Internal = isSameHost(base, links[i].Href)
+	}
+
+	return &LinksResult{
+		RetryInfo: info,
+		URL:       finalURL,
+		Links:     links,
+	}, nil
+}
+
+// isSameHost reports whether href points to the same host as base.
+func isSameHost(base *url.URL, href string) bool {
+	if base == nil {
+		return false
+	}
+
+	u, err := base.Parse(href)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Hostname(), base.Hostname())
+}
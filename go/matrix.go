@@ -0,0 +1,222 @@
+package screencraft
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultMatrixConcurrency is the default number of concurrent captures
+// made by CaptureMatrix.
+const DefaultMatrixConcurrency = 4
+
+// Device describes an emulated device for matrix captures.
+type Device struct {
+	// Name identifies the device in results (e.g., "iphone-13").
+	Name string
+	// Viewport sets the browser viewport dimensions.
+	Viewport *Viewport
+	// DeviceScaleFactor sets the device scale factor (DPR).
+	DeviceScaleFactor float64
+	// IsMobile emulates a mobile device.
+	IsMobile bool
+	// HasTouch enables touch event emulation.
+	HasTouch bool
+	// UserAgent sets a custom user agent string.
+	UserAgent string
+}
+
+var (
+	// DeviceDesktop is a 1920x1080 desktop browser.
+	DeviceDesktop = Device{
+		Name:     "desktop",
+		Viewport: &Viewport{Width: 1920, Height: 1080},
+	}
+
+	// DeviceMobile is a 375x812 mobile phone with touch support.
+	DeviceMobile = Device{
+		Name:              "mobile",
+		Viewport:          &Viewport{Width: 375, Height: 812},
+		DeviceScaleFactor: 3,
+		IsMobile:          true,
+		HasTouch:          true,
+	}
+
+	// DeviceTablet is a 768x1024 tablet with touch support.
+	DeviceTablet = Device{
+		Name:              "tablet",
+		Viewport:          &Viewport{Width: 768, Height: 1024},
+		DeviceScaleFactor: 2,
+		IsMobile:          true,
+		HasTouch:          true,
+	}
+)
+
+// MatrixOptions represents options for a locale/timezone/device matrix capture.
+type MatrixOptions struct {
+	// Locales are the browser locales to capture (e.g., "en-US", "de-DE").
+	Locales []string
+	// Timezones are the IANA timezones to capture (e.g., "Europe/Berlin").
+	Timezones []string
+	// Devices are the devices to capture.
+	Devices []Device
+	// Base holds the options shared by all captures. Its URL, locale,
+	// timezone and device fields are overridden per cell.
+	Base *ScreenshotOptions
+	// Concurrency is the maximum number of concurrent captures.
+	// Defaults to DefaultMatrixConcurrency.
+	Concurrency int
+}
+
+// MatrixCell is the result of a single capture in a matrix.
+type MatrixCell struct {
+	// Locale is the browser locale of this capture.
+	Locale string
+	// Timezone is the browser timezone of this capture.
+	Timezone string
+	// Device is the device of this capture.
+	Device Device
+	// Result is the screenshot, if the capture succeeded.
+	Result *ScreenshotResult
+	// Err is the capture error, if any.
+	Err error
+}
+
+// MatrixResult contains the results of a matrix capture.
+type MatrixResult struct {
+	// URL is the captured URL.
+	URL string
+	// Cells contains one entry per combination, ordered by locale, then
+	// timezone, then device.
+	Cells []MatrixCell
+}
+
+// Get returns the cell for the given combination, or nil if there is none.
+func (r *MatrixResult) Get(locale, timezone, device string) *MatrixCell {
+	for i := range r.Cells {
+		c := &r.Cells[i]
+		if c.Locale == locale && c.Timezone == timezone && c.Device.Name == device {
+			return c
+		}
+	}
+	return nil
+}
+
+// Failed returns the cells whose capture failed.
+func (r *MatrixResult) Failed() []MatrixCell {
+	var failed []MatrixCell
+	for _, c := range r.Cells {
+		if c.Err != nil {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// CaptureMatrix captures the URL for every combination of locale, timezone
+// and device.
+//
+// Empty dimensions are left at the API defaults. Captures run concurrently;
+// individual failures are reported per cell rather than failing the matrix.
+//
+// Example:
+//
+//	result, err := client.CaptureMatrix(ctx, "https://example.com", screencraft.MatrixOptions{
+//	    Locales:   []string{"en-US", "de-DE", "ja-JP"},
+//	    Timezones: []string{"America/New_York", "Europe/Berlin"},
+//	    Devices:   []screencraft.Device{screencraft.DeviceDesktop, screencraft.DeviceMobile},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cell := result.Get("de-DE", "Europe/Berlin", "mobile")
+func (c *Client) CaptureMatrix(ctx context.Context, url string, opts MatrixOptions) (*MatrixResult, error) {
+	base := ScreenshotOptions{}
+	if opts.Base != nil {
+		base = *opts.Base
+	}
+	base.URL = url
+
+	if err := ValidateScreenshotOptions(&base); err != nil {
+		return nil, err
+	}
+
+	locales := opts.Locales
+	if len(locales) == 0 {
+		locales = []string{base.Locale}
+	}
+	timezones := opts.Timezones
+	if len(timezones) == 0 {
+		timezones = []string{base.Timezone}
+	}
+	devices := opts.Devices
+	if len(devices) == 0 {
+		devices = []Device{{}}
+	}
+
+	result := &MatrixResult{URL: url}
+	for _, locale := range locales {
+		for _, timezone := range timezones {
+			for _, device := range devices {
+				result.Cells = append(result.Cells, MatrixCell{
+					Locale:   locale,
+					Timezone: timezone,
+					Device:   device,
+				})
+			}
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultMatrixConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range result.Cells {
+		cell := &result.Cells[i]
+
+		select {
+		case <-ctx.Done():
+			cell.Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			cellOpts := base
+			cellOpts.Locale = cell.Locale
+			cellOpts.Timezone = cell.Timezone
+			cell.Device.apply(&cellOpts)
+
+			cell.Result, cell.Err = c.Screenshot(ctx, &cellOpts)
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// apply sets the device emulation fields on opts. Unset device fields leave
+// opts unchanged.
+func (d Device) apply(opts *ScreenshotOptions) {
+	if d.Viewport != nil {
+		opts.Viewport = d.Viewport
+	}
+	if d.DeviceScaleFactor > 0 {
+		opts.DeviceScaleFactor = d.DeviceScaleFactor
+	}
+	if d.IsMobile {
+		opts.IsMobile = true
+	}
+	if d.HasTouch {
+		opts.HasTouch = true
+	}
+	if d.UserAgent != "" {
+		opts.UserAgent = d.UserAgent
+	}
+}
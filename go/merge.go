@@ -0,0 +1,54 @@
+package screencraft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DancingTedDanson011/screencraft-go/internal/pdfutil"
+)
+
+// ErrNoPDFs is returned when MergePDFs is called without documents.
+var ErrNoPDFs = errors.New("screencraft: no PDFs to merge")
+
+// MergePDFs concatenates the pages of several PDFs into one document, in
+// argument order.
+//
+// Merging happens locally, without external tools. Page content, fonts,
+// images and links are preserved; document-level features such as
+// bookmarks, named destinations and forms are not. Encrypted PDFs are not
+// supported.
+//
+// Example:
+//
+//	cover, _ := client.PDF(ctx, &screencraft.PDFOptions{URL: "https://example.com/cover"})
+//	report, _ := client.PDF(ctx, &screencraft.PDFOptions{URL: "https://example.com/report"})
+//
+//	merged, err := screencraft.MergePDFs(cover, report)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("report.pdf", merged.Data, 0644)
+func MergePDFs(results ...*PDFResult) (*PDFResult, error) {
+	if len(results) == 0 {
+		return nil, ErrNoPDFs
+	}
+
+	docs := make([][]byte, len(results))
+	for i, r := range results {
+		if r == nil || len(r.Data) == 0 {
+			return nil, fmt.Errorf("screencraft: PDF %d has no data", i+1)
+		}
+		docs[i] = r.Data
+	}
+
+	data, pages, err := pdfutil.Merge(docs...)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to merge PDFs: %w", err)
+	}
+
+	return &PDFResult{
+		Data:        data,
+		ContentType: "application/pdf",
+		Pages:       pages,
+	}, nil
+}
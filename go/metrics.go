@@ -0,0 +1,189 @@
+package screencraft
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MetricsCollector receives measurements of the client's HTTP traffic, e.g.
+// to export them to Prometheus. endpoint is the API path template such as
+// "/screenshots", so it is safe to use as a label. Implementations must be
+// safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called after each attempt with the HTTP status code
+	// (0 if no response was received), the time until the response headers
+	// arrived and the attempt's error, if any.
+	ObserveRequest(endpoint string, statusCode int, latency time.Duration, err error)
+	// IncRetries is called whenever a request is retried.
+	IncRetries(endpoint string)
+	// IncRateLimited is called whenever the API responds with 429.
+	IncRateLimited(endpoint string)
+	// AddBytesDownloaded is called with the size of each response body read.
+	AddBytesDownloaded(endpoint string, n int64)
+}
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram
+// reported by Client.Stats.
+var DefaultLatencyBuckets = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+}
+
+// WithMetrics reports the client's HTTP traffic to collector.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithMetrics(promCollector))
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = collector
+	}
+}
+
+// Stats is a snapshot of the client's HTTP traffic since it was created.
+type Stats struct {
+	// Requests is the number of attempts made, including retries.
+	Requests uint64
+	// Errors is the number of attempts that failed.
+	Errors uint64
+	// Retries is the number of retries.
+	Retries uint64
+	// RateLimited is the number of 429 responses.
+	RateLimited uint64
+	// BytesDownloaded is the total size of response bodies read.
+	BytesDownloaded uint64
+	// LatencySum is the total latency of all attempts.
+	LatencySum time.Duration
+	// LatencyBuckets holds the cumulative number of attempts with a latency
+	// of at most the bucket's bound, for each of DefaultLatencyBuckets.
+	LatencyBuckets map[time.Duration]uint64
+}
+
+// Stats returns a snapshot of the client's HTTP traffic.
+//
+// Example:
+//
+//	stats := client.Stats()
+//	fmt.Printf("%d requests, %d retries, %d rate limited\n",
+//	    stats.Requests, stats.Retries, stats.RateLimited)
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// clientStats accumulates the counters behind Client.Stats.
+type clientStats struct {
+	requests        atomic.Uint64
+	errors          atomic.Uint64
+	retries         atomic.Uint64
+	rateLimited     atomic.Uint64
+	bytesDownloaded atomic.Uint64
+
+	mu         sync.Mutex
+	latencySum time.Duration
+	buckets    []uint64
+}
+
+// newClientStats creates empty stats.
+func newClientStats() *clientStats {
+	return &clientStats{buckets: make([]uint64, len(DefaultLatencyBuckets))}
+}
+
+// observe records an attempt.
+func (s *clientStats) observe(latency time.Duration, failed bool) {
+	s.requests.Add(1)
+	if failed {
+		s.errors.Add(1)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencySum += latency
+	for i, bound := range DefaultLatencyBuckets {
+		if latency <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+// snapshot returns the current counters.
+func (s *clientStats) snapshot() Stats {
+	stats := Stats{
+		Requests:        s.requests.Load(),
+		Errors:          s.errors.Load(),
+		Retries:         s.retries.Load(),
+		RateLimited:     s.rateLimited.Load(),
+		BytesDownloaded: s.bytesDownloaded.Load(),
+		LatencyBuckets:  make(map[time.Duration]uint64, len(DefaultLatencyBuckets)),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats.LatencySum = s.latencySum
+	for i, bound := range DefaultLatencyBuckets {
+		stats.LatencyBuckets[bound] = s.buckets[i]
+	}
+	return stats
+}
+
+// observeAttempt records the outcome of an attempt.
+func (c *Client) observeAttempt(endpoint string, statusCode int, latency time.Duration, err error) {
+	c.stats.observe(latency, err != nil)
+	if statusCode == http.StatusTooManyRequests {
+		c.stats.rateLimited.Add(1)
+	}
+
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ObserveRequest(endpoint, statusCode, latency, err)
+	if statusCode == http.StatusTooManyRequests {
+		c.metrics.IncRateLimited(endpoint)
+	}
+}
+
+// observeRetry records a retry.
+func (c *Client) observeRetry(endpoint string) {
+	c.stats.retries.Add(1)
+	if c.metrics != nil {
+		c.metrics.IncRetries(endpoint)
+	}
+}
+
+// countingBody reports the number of bytes read from a response body when
+// it is closed.
+type countingBody struct {
+	io.ReadCloser
+	client   *Client
+	endpoint string
+	n        int64
+	once     sync.Once
+}
+
+// Read implements io.Reader.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// Close closes the body and reports the bytes read.
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.client.stats.bytesDownloaded.Add(uint64(b.n))
+		if b.client.metrics != nil {
+			b.client.metrics.AddBytesDownloaded(b.endpoint, b.n)
+		}
+	})
+	return err
+}
@@ -0,0 +1,249 @@
+// Package pagesize provides paper dimensions and unit conversions for PDF
+// generation.
+//
+// It covers every screencraft.PDFFormat plus the ISO A and B series and
+// custom sizes, so layout code computing viewports for print output does not
+// need to hard-code dimensions.
+//
+// Basic usage:
+//
+//	viewport, err := pagesize.FitViewportToPage(screencraft.A4, pagesize.CSSPixelDPI)
+//	// viewport == &screencraft.Viewport{Width: 794, Height: 1123}
+package pagesize
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+)
+
+const (
+	// MMPerInch is the number of millimeters in an inch.
+	MMPerInch = 25.4
+
+	// CSSPixelDPI is the resolution of a CSS pixel (96 per inch).
+	CSSPixelDPI = 96
+
+	// PointsPerInch is the number of PostScript points in an inch.
+	PointsPerInch = 72
+)
+
+// Unit is a length unit.
+type Unit string
+
+const (
+	// Millimeter is the millimeter unit.
+	Millimeter Unit = "mm"
+	// Centimeter is the centimeter unit.
+	Centimeter Unit = "cm"
+	// Inch is the inch unit.
+	Inch Unit = "in"
+	// Pixel is the CSS pixel unit (1/96 inch).
+	Pixel Unit = "px"
+	// Point is the PostScript point unit (1/72 inch).
+	Point Unit = "pt"
+)
+
+// Size is a portrait paper size.
+type Size struct {
+	// Name identifies the size (e.g., "A4"). Empty for custom sizes.
+	Name string
+	// WidthMM is the width in millimeters.
+	WidthMM float64
+	// HeightMM is the height in millimeters.
+	HeightMM float64
+}
+
+// ISO A series.
+var (
+	A0 = Size{Name: "A0", WidthMM: 841, HeightMM: 1189}
+	A1 = Size{Name: "A1", WidthMM: 594, HeightMM: 841}
+	A2 = Size{Name: "A2", WidthMM: 420, HeightMM: 594}
+	A3 = Size{Name: "A3", WidthMM: 297, HeightMM: 420}
+	A4 = Size{Name: "A4", WidthMM: 210, HeightMM: 297}
+	A5 = Size{Name: "A5", WidthMM: 148, HeightMM: 210}
+	A6 = Size{Name: "A6", WidthMM: 105, HeightMM: 148}
+)
+
+// ISO B series.
+var (
+	B0 = Size{Name: "B0", WidthMM: 1000, HeightMM: 1414}
+	B1 = Size{Name: "B1", WidthMM: 707, HeightMM: 1000}
+	B2 = Size{Name: "B2", WidthMM: 500, HeightMM: 707}
+	B3 = Size{Name: "B3", WidthMM: 353, HeightMM: 500}
+	B4 = Size{Name: "B4", WidthMM: 250, HeightMM: 353}
+	B5 = Size{Name: "B5", WidthMM: 176, HeightMM: 250}
+	B6 = Size{Name: "B6", WidthMM: 125, HeightMM: 176}
+)
+
+// North American sizes.
+var (
+	Letter  = Size{Name: "Letter", WidthMM: 215.9, HeightMM: 279.4}
+	Legal   = Size{Name: "Legal", WidthMM: 215.9, HeightMM: 355.6}
+	Tabloid = Size{Name: "Tabloid", WidthMM: 279.4, HeightMM: 431.8}
+)
+
+// formats maps API paper formats to their sizes.
+var formats = map[screencraft.PDFFormat]Size{
+	screencraft.A3:      A3,
+	screencraft.A4:      A4,
+	screencraft.A5:      A5,
+	screencraft.Letter:  Letter,
+	screencraft.Legal:   Legal,
+	screencraft.Tabloid: Tabloid,
+}
+
+// Of returns the size of an API paper format.
+func Of(format screencraft.PDFFormat) (Size, error) {
+	size, ok := formats[format]
+	if !ok {
+		return Size{}, fmt.Errorf("%w: %q", screencraft.ErrInvalidPDFFormat, format)
+	}
+	return size, nil
+}
+
+// Custom returns a custom size with the given dimensions.
+func Custom(width, height float64, unit Unit) (Size, error) {
+	w, err := ToMM(width, unit)
+	if err != nil {
+		return Size{}, err
+	}
+	h, err := ToMM(height, unit)
+	if err != nil {
+		return Size{}, err
+	}
+	return Size{WidthMM: w, HeightMM: h}, nil
+}
+
+// Landscape returns the size rotated to landscape orientation.
+func (s Size) Landscape() Size {
+	if s.WidthMM > s.HeightMM {
+		return s
+	}
+	return Size{Name: s.Name, WidthMM: s.HeightMM, HeightMM: s.WidthMM}
+}
+
+// Orient returns the size in the given orientation.
+func (s Size) Orient(o screencraft.PDFOrientation) Size {
+	if o == screencraft.Landscape {
+		return s.Landscape()
+	}
+	if s.WidthMM > s.HeightMM {
+		return Size{Name: s.Name, WidthMM: s.HeightMM, HeightMM: s.WidthMM}
+	}
+	return s
+}
+
+// In returns the dimensions in the given unit.
+func (s Size) In(unit Unit) (width, height float64, err error) {
+	width, err = FromMM(s.WidthMM, unit)
+	if err != nil {
+		return 0, 0, err
+	}
+	height, err = FromMM(s.HeightMM, unit)
+	return width, height, err
+}
+
+// Inches returns the dimensions in inches.
+func (s Size) Inches() (width, height float64) {
+	return s.WidthMM / MMPerInch, s.HeightMM / MMPerInch
+}
+
+// Pixels returns the dimensions in pixels at the given resolution, rounded to
+// the nearest pixel.
+func (s Size) Pixels(dpi float64) (width, height int) {
+	w, h := s.Inches()
+	return int(math.Round(w * dpi)), int(math.Round(h * dpi))
+}
+
+// CSS returns the dimensions as CSS lengths in millimeters, suitable for
+// PDFOptions.Width and PDFOptions.Height.
+func (s Size) CSS() (width, height string) {
+	return formatMM(s.WidthMM), formatMM(s.HeightMM)
+}
+
+// Apply sets a custom page size on PDF options.
+func (s Size) Apply(opts *screencraft.PDFOptions) {
+	opts.Format = ""
+	opts.Width, opts.Height = s.CSS()
+}
+
+// FitViewportToPage returns a viewport matching the printable page size of
+// format at the given resolution. Use CSSPixelDPI to match how browsers lay
+// out print pages.
+func FitViewportToPage(format screencraft.PDFFormat, dpi float64) (*screencraft.Viewport, error) {
+	if dpi <= 0 {
+		return nil, fmt.Errorf("pagesize: dpi must be positive")
+	}
+
+	size, err := Of(format)
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := size.Pixels(dpi)
+	return &screencraft.Viewport{Width: w, Height: h}, nil
+}
+
+// ToMM converts a length in the given unit to millimeters.
+func ToMM(v float64, unit Unit) (float64, error) {
+	switch unit {
+	case Millimeter:
+		return v, nil
+	case Centimeter:
+		return v * 10, nil
+	case Inch:
+		return v * MMPerInch, nil
+	case Pixel:
+		return v * MMPerInch / CSSPixelDPI, nil
+	case Point:
+		return v * MMPerInch / PointsPerInch, nil
+	}
+	return 0, fmt.Errorf("pagesize: unknown unit %q", unit)
+}
+
+// FromMM converts a length in millimeters to the given unit.
+func FromMM(mm float64, unit Unit) (float64, error) {
+	switch unit {
+	case Millimeter:
+		return mm, nil
+	case Centimeter:
+		return mm / 10, nil
+	case Inch:
+		return mm / MMPerInch, nil
+	case Pixel:
+		return mm / MMPerInch * CSSPixelDPI, nil
+	case Point:
+		return mm / MMPerInch * PointsPerInch, nil
+	}
+	return 0, fmt.Errorf("pagesize: unknown unit %q", unit)
+}
+
+// ParseLength parses a CSS length such as "8.5in", "210mm" or "100px" and
+// returns it in millimeters. A number without a unit is treated as pixels.
+func ParseLength(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	unit := Pixel
+	for _, u := range []Unit{Millimeter, Centimeter, Inch, Pixel, Point} {
+		if strings.HasSuffix(s, string(u)) {
+			unit = u
+			s = strings.TrimSpace(strings.TrimSuffix(s, string(u)))
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("pagesize: invalid length %q", s)
+	}
+	return ToMM(v, unit)
+}
+
+// formatMM formats a millimeter value as a CSS length.
+func formatMM(mm float64) string {
+	return strconv.FormatFloat(mm, 'f', -1, 64) + "mm"
+}
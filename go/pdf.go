@@ -2,11 +2,11 @@ package screencraft
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 const (
@@ -30,21 +30,91 @@ const (
 //	    log.Fatal(err)
 //	}
 //	os.WriteFile("document.pdf", result.Data, 0644)
-func (c *Client) PDF(ctx context.Context, opts *PDFOptions) (*PDFResult, error) {
+func (c *Client) PDF(ctx context.Context, opts *PDFOptions, callOpts ...CallOption) (*PDFResult, error) {
+	ctx = withCallOptions(ctx, callOpts)
+
 	if err := ValidatePDFOptions(opts); err != nil {
 		return nil, err
 	}
 
+	// Captures delivered by webhook or uploaded to storage are never served
+	// from the cache or coalesced
+	var cacheKey, flightKey string
+	if opts.Webhook == nil && opts.Storage == nil {
+		cacheKey = c.cacheKey("pdf", opts)
+		ctx = c.withRevalidation(ctx, cacheKey)
+		flightKey = c.flightKey(ctx, "pdf", opts)
+	}
+	if cached, ok := c.cachedResult(cacheKey); ok {
+		if r, ok := cached.(*PDFResult); ok {
+			result := *r
+			result.RetryInfo = RetryInfo{}
+			result.ResponseInfo = ResponseInfo{}
+			result.FromCache = true
+			return &result, nil
+		}
+	}
+
+	if flightKey != "" {
+		v, err := c.flights.do(ctx, flightKey, func(ctx context.Context) (interface{}, error) {
+			return c.capturePDF(ctx, opts, cacheKey)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sharedPDF(v.(*PDFResult)), nil
+	}
+
+	return c.capturePDF(ctx, opts, cacheKey)
+}
+
+// capturePDF sends a synchronous capture request and caches its result under
+// cacheKey.
+func (c *Client) capturePDF(ctx context.Context, opts *PDFOptions, cacheKey string) (*PDFResult, error) {
 	// Build request body
 	reqBody := c.buildPDFRequest(opts)
 
-	resp, err := c.doRequest(ctx, http.MethodPost, pdfEndpoint, reqBody)
+	start := time.Now()
+	resp, info, err := c.doRequest(ctx, http.MethodPost, pdfEndpoint, reqBody)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return c.parsePDFResponse(resp, opts)
+	if resp.StatusCode == http.StatusNotModified {
+		stale, ok := c.notModifiedResult(cacheKey, callOptionsFrom(ctx).ifNoneMatch)
+		r, isResult := stale.(*PDFResult)
+		if !ok || !isResult {
+			return nil, ErrNotModified
+		}
+
+		stored := *r
+		stored.CacheInfo = revalidatedCacheInfo(r.CacheInfo, resp.Header)
+		c.storeResult(cacheKey, &stored, stored.CacheInfo)
+
+		result := stored
+		result.RetryInfo = info
+		result.ResponseInfo = newResponseInfo(resp, start)
+		result.FromCache = true
+		return &result, nil
+	}
+
+	result, err := c.parsePDFResponse(resp, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.ResponseInfo = newResponseInfo(resp, start)
+	result.RetryInfo = info
+	result.CacheInfo = cacheInfoFromHeader(resp.Header)
+	result.CapturedAt = time.Now().UTC()
+	result.OptionsHash = OptionsFingerprint(opts)
+
+	if len(result.Data) > 0 {
+		stored := *result
+		c.storeResult(cacheKey, &stored, result.CacheInfo)
+	}
+
+	return result, nil
 }
 
 // PDFAsync generates a PDF asynchronously using webhooks.
@@ -66,7 +136,9 @@ func (c *Client) PDF(ctx context.Context, opts *PDFOptions) (*PDFResult, error)
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Job ID: %s\n", jobID)
-func (c *Client) PDFAsync(ctx context.Context, opts *PDFOptions) (string, error) {
+func (c *Client) PDFAsync(ctx context.Context, opts *PDFOptions, callOpts ...CallOption) (string, error) {
+	ctx = withCallOptions(ctx, callOpts)
+
 	if err := ValidatePDFOptions(opts); err != nil {
 		return "", err
 	}
@@ -75,10 +147,21 @@ func (c *Client) PDFAsync(ctx context.Context, opts *PDFOptions) (string, error)
 		return "", NewValidationError("webhook.url", "webhook URL is required for async operations", "required").Error
 	}
 
+	if c.jobs != nil {
+		return c.trackJob(ctx, "pdf", opts, func(ctx context.Context) (string, error) {
+			return c.pdfAsync(ctx, opts)
+		})
+	}
+
+	return c.pdfAsync(ctx, opts)
+}
+
+// pdfAsync submits an asynchronous capture request and returns its job ID.
+func (c *Client) pdfAsync(ctx context.Context, opts *PDFOptions) (string, error) {
 	// Build request body
 	reqBody := c.buildPDFRequest(opts)
 
-	resp, err := c.doRequest(ctx, http.MethodPost, pdfEndpoint, reqBody)
+	resp, _, err := c.doRequest(ctx, http.MethodPost, pdfEndpoint, reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -91,7 +174,7 @@ func (c *Client) PDFAsync(ctx context.Context, opts *PDFOptions) (string, error)
 	}
 
 	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := c.decodeJSON(body, &apiResp); err != nil {
 		return "", fmt.Errorf("screencraft: failed to parse response: %w", err)
 	}
 
@@ -107,8 +190,12 @@ func (c *Client) PDFAsync(ctx context.Context, opts *PDFOptions) (string, error)
 
 // buildPDFRequest builds the API request body for PDF generation.
 func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
-	req := map[string]interface{}{
-		"url": opts.URL,
+	req := map[string]interface{}{}
+
+	if len(opts.URLs) > 0 {
+		req["urls"] = opts.URLs
+	} else {
+		req["url"] = opts.URL
 	}
 
 	if opts.Format != "" {
@@ -155,6 +242,14 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 		req["pageRanges"] = opts.PageRanges
 	}
 
+	if opts.GenerateOutline {
+		req["generateOutline"] = true
+	}
+
+	if opts.TOCTemplate != "" {
+		req["tocTemplate"] = opts.TOCTemplate
+	}
+
 	if opts.Margin != nil {
 		margin := map[string]interface{}{}
 		if opts.Margin.Top != "" {
@@ -191,6 +286,30 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 		req["acceptCookies"] = true
 	}
 
+	if len(opts.HideSelectors) > 0 {
+		req["hideSelectors"] = opts.HideSelectors
+	}
+
+	if opts.InjectCSS != "" {
+		req["injectCSS"] = opts.InjectCSS
+	}
+
+	if opts.InjectCSSURL != "" {
+		req["injectCSSURL"] = opts.InjectCSSURL
+	}
+
+	if len(opts.Actions) > 0 {
+		req["actions"] = opts.Actions
+	}
+
+	if opts.Login != nil {
+		req["login"] = opts.Login
+	}
+
+	if opts.BasicAuth != nil {
+		req["basicAuth"] = opts.BasicAuth
+	}
+
 	if opts.Delay > 0 {
 		req["delay"] = opts.Delay
 	}
@@ -203,10 +322,46 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 		req["waitForSelector"] = opts.WaitForSelector
 	}
 
+	if len(opts.WaitForSelectors) > 0 {
+		req["waitForSelectors"] = opts.WaitForSelectors
+		if opts.WaitMode != "" {
+			req["waitMode"] = opts.WaitMode
+		}
+	}
+
 	if opts.WaitForTimeout > 0 {
 		req["waitForTimeout"] = opts.WaitForTimeout
 	}
 
+	if opts.WaitForFunction != "" {
+		waitFn := map[string]interface{}{
+			"expression": opts.WaitForFunction,
+		}
+		if opts.WaitForFunctionTimeout > 0 {
+			waitFn["timeout"] = opts.WaitForFunctionTimeout
+		}
+		req["waitForFunction"] = waitFn
+	}
+
+	if opts.WaitForRequest != "" {
+		req["waitForRequest"] = opts.WaitForRequest
+	}
+
+	if opts.WaitForResponse != "" {
+		req["waitForResponse"] = opts.WaitForResponse
+	}
+
+	if opts.ScrollThrough {
+		scroll := map[string]interface{}{}
+		if opts.ScrollStep > 0 {
+			scroll["step"] = opts.ScrollStep
+		}
+		if opts.ScrollPause > 0 {
+			scroll["pause"] = opts.ScrollPause
+		}
+		req["scrollThrough"] = scroll
+	}
+
 	if len(opts.Cookies) > 0 {
 		req["cookies"] = opts.Cookies
 	}
@@ -219,10 +374,34 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 		req["userAgent"] = opts.UserAgent
 	}
 
+	if opts.Locale != "" {
+		req["locale"] = opts.Locale
+	}
+
+	if opts.Timezone != "" {
+		req["timezone"] = opts.Timezone
+	}
+
 	if opts.DarkMode {
 		req["darkMode"] = true
 	}
 
+	if opts.EmulateMedia != nil {
+		media := map[string]interface{}{}
+		if opts.EmulateMedia.ReducedMotion != "" {
+			media["reducedMotion"] = opts.EmulateMedia.ReducedMotion
+		}
+		if opts.EmulateMedia.ForcedColors != "" {
+			media["forcedColors"] = opts.EmulateMedia.ForcedColors
+		}
+		if opts.EmulateMedia.Contrast != "" {
+			media["contrast"] = opts.EmulateMedia.Contrast
+		}
+		if len(media) > 0 {
+			req["emulateMedia"] = media
+		}
+	}
+
 	if opts.BlockAds {
 		req["blockAds"] = true
 	}
@@ -231,6 +410,10 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 		req["blockTrackers"] = true
 	}
 
+	if len(opts.BlockURLPatterns) > 0 {
+		req["blockURLPatterns"] = opts.BlockURLPatterns
+	}
+
 	if opts.BypassCSP {
 		req["bypassCSP"] = true
 	}
@@ -239,6 +422,38 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 		req["javascript"] = *opts.JavaScript
 	}
 
+	if len(opts.AssertTextPresent) > 0 {
+		req["assertTextPresent"] = opts.AssertTextPresent
+	}
+
+	if len(opts.AssertTextAbsent) > 0 {
+		req["assertTextAbsent"] = opts.AssertTextAbsent
+	}
+
+	if opts.Geolocation != nil {
+		req["geolocation"] = opts.Geolocation
+	}
+
+	if opts.Proxy != nil {
+		req["proxy"] = opts.Proxy
+	}
+
+	if opts.Watermark != nil {
+		req["watermark"] = opts.Watermark
+	}
+
+	if opts.Storage != nil {
+		req["storage"] = opts.Storage
+	}
+
+	if opts.Priority != PriorityNormal {
+		req["priority"] = opts.Priority.String()
+	}
+
+	if opts.ClientReference != "" {
+		req["clientReference"] = opts.ClientReference
+	}
+
 	if opts.Webhook != nil {
 		webhook := map[string]interface{}{
 			"url": opts.Webhook.URL,
@@ -255,6 +470,18 @@ func (c *Client) buildPDFRequest(opts *PDFOptions) map[string]interface{} {
 	return req
 }
 
+// pdfResponse is the JSON API response for a PDF request.
+type pdfResponse struct {
+	APIResponse
+	Data *pdfData `json:"data,omitempty"`
+}
+
+// pdfData is the data of a PDF uploaded to storage.
+type pdfData struct {
+	Pages   int           `json:"pages,omitempty"`
+	Storage *StoredObject `json:"storage,omitempty"`
+}
+
 // parsePDFResponse parses the PDF response from the API.
 func (c *Client) parsePDFResponse(resp *http.Response, opts *PDFOptions) (*PDFResult, error) {
 	contentType := resp.Header.Get("Content-Type")
@@ -266,8 +493,8 @@ func (c *Client) parsePDFResponse(resp *http.Response, opts *PDFOptions) (*PDFRe
 			return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
 		}
 
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err != nil {
+		var apiResp pdfResponse
+		if err := c.decodeJSON(body, &apiResp); err != nil {
 			return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
 		}
 
@@ -278,6 +505,17 @@ func (c *Client) parsePDFResponse(resp *http.Response, opts *PDFOptions) (*PDFRe
 			}
 		}
 
+		// PDF uploaded to storage
+		if apiResp.Data != nil {
+			return &PDFResult{
+				ContentType: "application/pdf",
+				URL:         opts.URL,
+				Pages:       apiResp.Data.Pages,
+				JobID:       apiResp.JobID,
+				Storage:     apiResp.Data.Storage,
+			}, nil
+		}
+
 		// Async response
 		return &PDFResult{
 			URL:   opts.URL,
@@ -304,9 +542,42 @@ func (c *Client) parsePDFResponse(resp *http.Response, opts *PDFOptions) (*PDFRe
 		}
 	}
 
+	assertions, err := c.parseAssertionsHeader(resp)
+	if err != nil {
+		return nil, err
+	}
+	result.Assertions = assertions
+
+	// Per-URL outcome of multi-URL PDFs
+	if sections := resp.Header.Get("X-PDF-Sections"); sections != "" {
+		if err := c.decodeJSON([]byte(sections), &result.Sections); err != nil {
+			return nil, fmt.Errorf("screencraft: failed to parse PDF sections: %w", err)
+		}
+	}
+
 	return result, nil
 }
 
+// pdfTargetURL returns the URL of a PDF, or the first of its URLs.
+func pdfTargetURL(opts *PDFOptions) string {
+	if opts.URL == "" && len(opts.URLs) > 0 {
+		return opts.URLs[0]
+	}
+	return opts.URL
+}
+
+// FailedSections returns the sections of a multi-URL PDF that could not be
+// rendered.
+func (r *PDFResult) FailedSections() []PDFSection {
+	var failed []PDFSection
+	for _, s := range r.Sections {
+		if s.Failed() {
+			failed = append(failed, s)
+		}
+	}
+	return failed
+}
+
 // PDFURL generates a PDF with minimal options.
 //
 // This is a convenience method for simple PDF generation.
@@ -0,0 +1,250 @@
+// Package pdfdiff compares PDF outputs page by page.
+//
+// Both documents are rasterized and each page pair is compared pixel by pixel,
+// so template regressions (e.g. in invoices or reports) can gate CI the same
+// way screenshot diffs do. The default rasterizer runs pdftoppm from
+// poppler-utils, which must be on PATH; set Options.Rasterizer otherwise.
+//
+// Basic usage:
+//
+//	report, err := pdfdiff.Compare(baseline, current, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !report.Equal() {
+//	    for _, page := range report.Pages {
+//	        fmt.Printf("page %d: %.2f%% different\n", page.Page, page.DiffRatio*100)
+//	    }
+//	}
+package pdfdiff
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+)
+
+const (
+	// DefaultDPI is the default rasterization resolution.
+	DefaultDPI = 72
+
+	// DefaultTolerance is the default per-channel color tolerance (0-255).
+	DefaultTolerance = 8
+)
+
+// ErrMissingPDF is returned when one of the compared results has no data.
+var ErrMissingPDF = errors.New("pdfdiff: PDF data is required")
+
+// Options configures a comparison.
+type Options struct {
+	// Rasterizer renders PDF pages to images. Defaults to PdftoppmRasterizer,
+	// which requires pdftoppm (poppler-utils) on PATH.
+	Rasterizer Rasterizer
+
+	// DPI is the rasterization resolution. Defaults to DefaultDPI.
+	DPI int
+
+	// Tolerance is the maximum per-channel difference (0-255) for two
+	// pixels to be considered equal. Defaults to DefaultTolerance if nil;
+	// use screencraft.Int(0) to require exact matches.
+	Tolerance *int
+
+	// MaxDiffRatio is the fraction of differing pixels (0-1) a page may have
+	// and still be considered equal. Defaults to 0.
+	MaxDiffRatio float64
+
+	// DiffImages generates a highlight image for pages that differ.
+	DiffImages bool
+}
+
+// PageDiff describes the differences on a single page.
+type PageDiff struct {
+	// Page is the 1-based page number.
+	Page int
+
+	// DiffPixels is the number of differing pixels.
+	DiffPixels int
+
+	// DiffRatio is the fraction of differing pixels (0-1).
+	DiffRatio float64
+
+	// Bounds is the smallest rectangle containing all differing pixels.
+	Bounds image.Rectangle
+
+	// SizeMismatch is true if the pages have different dimensions.
+	SizeMismatch bool
+
+	// Missing is true if the page only exists in one of the documents.
+	Missing bool
+
+	// Diff highlights differing pixels in red, if Options.DiffImages is set.
+	Diff image.Image
+
+	// Equal is true if the page is within the configured thresholds.
+	Equal bool
+}
+
+// Report is the result of comparing two PDFs.
+type Report struct {
+	// PagesA is the page count of the first document.
+	PagesA int
+
+	// PagesB is the page count of the second document.
+	PagesB int
+
+	// Pages contains one entry per page of the longer document.
+	Pages []PageDiff
+}
+
+// Equal reports whether both documents have the same page count and all
+// pages are within the configured thresholds.
+func (r *Report) Equal() bool {
+	if r.PagesA != r.PagesB {
+		return false
+	}
+	for _, p := range r.Pages {
+		if !p.Equal {
+			return false
+		}
+	}
+	return true
+}
+
+// Changed returns the pages that are not equal.
+func (r *Report) Changed() []PageDiff {
+	var pages []PageDiff
+	for _, p := range r.Pages {
+		if !p.Equal {
+			pages = append(pages, p)
+		}
+	}
+	return pages
+}
+
+// Compare rasterizes both PDFs and reports per-page visual differences.
+func Compare(a, b *screencraft.PDFResult, opts *Options) (*Report, error) {
+	if a == nil || b == nil || len(a.Data) == 0 || len(b.Data) == 0 {
+		return nil, ErrMissingPDF
+	}
+
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Rasterizer == nil {
+		o.Rasterizer = PdftoppmRasterizer{}
+	}
+	if o.DPI <= 0 {
+		o.DPI = DefaultDPI
+	}
+	if o.Tolerance == nil {
+		o.Tolerance = screencraft.Int(DefaultTolerance)
+	}
+
+	pagesA, err := o.Rasterizer.Rasterize(a.Data, o.DPI)
+	if err != nil {
+		return nil, fmt.Errorf("pdfdiff: failed to rasterize first PDF: %w", err)
+	}
+
+	pagesB, err := o.Rasterizer.Rasterize(b.Data, o.DPI)
+	if err != nil {
+		return nil, fmt.Errorf("pdfdiff: failed to rasterize second PDF: %w", err)
+	}
+
+	report := &Report{
+		PagesA: len(pagesA),
+		PagesB: len(pagesB),
+	}
+
+	n := len(pagesA)
+	if len(pagesB) > n {
+		n = len(pagesB)
+	}
+
+	for i := 0; i < n; i++ {
+		if i >= len(pagesA) || i >= len(pagesB) {
+			report.Pages = append(report.Pages, PageDiff{Page: i + 1, Missing: true})
+			continue
+		}
+
+		diff := comparePage(pagesA[i], pagesB[i], &o)
+		diff.Page = i + 1
+		report.Pages = append(report.Pages, diff)
+	}
+
+	return report, nil
+}
+
+// comparePage compares two rasterized pages.
+func comparePage(a, b image.Image, o *Options) PageDiff {
+	ba, bb := a.Bounds(), b.Bounds()
+	if ba.Dx() != bb.Dx() || ba.Dy() != bb.Dy() {
+		return PageDiff{SizeMismatch: true, DiffRatio: 1}
+	}
+
+	var highlight *image.RGBA
+	if o.DiffImages {
+		highlight = image.NewRGBA(image.Rect(0, 0, ba.Dx(), ba.Dy()))
+	}
+
+	var d PageDiff
+	for y := 0; y < ba.Dy(); y++ {
+		for x := 0; x < ba.Dx(); x++ {
+			ca := a.At(ba.Min.X+x, ba.Min.Y+y)
+			cb := b.At(bb.Min.X+x, bb.Min.Y+y)
+
+			if pixelsEqual(ca, cb, *o.Tolerance) {
+				if highlight != nil {
+					highlight.Set(x, y, faded(ca))
+				}
+				continue
+			}
+
+			d.DiffPixels++
+			d.Bounds = d.Bounds.Union(image.Rect(x, y, x+1, y+1))
+			if highlight != nil {
+				highlight.Set(x, y, color.RGBA{R: 255, A: 255})
+			}
+		}
+	}
+
+	total := ba.Dx() * ba.Dy()
+	if total > 0 {
+		d.DiffRatio = float64(d.DiffPixels) / float64(total)
+	}
+	d.Equal = d.DiffRatio <= o.MaxDiffRatio
+	if highlight != nil && !d.Equal {
+		d.Diff = highlight
+	}
+
+	return d
+}
+
+// pixelsEqual reports whether all channels of a and b are within tolerance.
+func pixelsEqual(a, b color.Color, tolerance int) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+
+	return channelDelta(r1, r2) <= tolerance &&
+		channelDelta(g1, g2) <= tolerance &&
+		channelDelta(b1, b2) <= tolerance &&
+		channelDelta(a1, a2) <= tolerance
+}
+
+// channelDelta returns the 8-bit difference between two 16-bit channels.
+func channelDelta(a, b uint32) int {
+	d := int(a>>8) - int(b>>8)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// faded returns a light gray version of c for unchanged diff image pixels.
+func faded(c color.Color) color.Color {
+	g := color.GrayModel.Convert(c).(color.Gray)
+	return color.Gray{Y: 192 + g.Y/4}
+}
@@ -0,0 +1,82 @@
+package pdfdiff
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// Rasterizer renders the pages of a PDF document to images.
+type Rasterizer interface {
+	// Rasterize returns one image per page at the given resolution.
+	Rasterize(pdf []byte, dpi int) ([]image.Image, error)
+}
+
+// RasterizerFunc adapts a function to the Rasterizer interface.
+type RasterizerFunc func(pdf []byte, dpi int) ([]image.Image, error)
+
+// Rasterize calls f(pdf, dpi).
+func (f RasterizerFunc) Rasterize(pdf []byte, dpi int) ([]image.Image, error) {
+	return f(pdf, dpi)
+}
+
+// PdftoppmRasterizer rasterizes PDFs with the pdftoppm tool from poppler-utils.
+type PdftoppmRasterizer struct {
+	// Path is the pdftoppm executable. Defaults to "pdftoppm" in PATH.
+	Path string
+}
+
+// Rasterize renders all pages of pdf to PNG images using pdftoppm.
+func (r PdftoppmRasterizer) Rasterize(pdf []byte, dpi int) ([]image.Image, error) {
+	bin := r.Path
+	if bin == "" {
+		bin = "pdftoppm"
+	}
+
+	dir, err := os.MkdirTemp("", "pdfdiff")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, pdf, 0600); err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(bin, "-r", strconv.Itoa(dpi), "-png", input, filepath.Join(dir, "page"))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", bin, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	// pdftoppm zero-pads page numbers to the same width, so lexical order
+	// matches page order.
+	files, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	pages := make([]image.Image, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, img)
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,187 @@
+package screencraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	pdfImagesEndpoint = "/pdfs/images"
+)
+
+// ErrMissingPDF is returned when PDFToImages is called without a document.
+var ErrMissingPDF = errors.New("screencraft: PDF data or job ID is required")
+
+// PDFSource identifies the document to rasterize. Set exactly one of Data or
+// JobID.
+type PDFSource struct {
+	// Data is the raw PDF document.
+	Data []byte
+	// JobID is the ID of a completed async PDF job.
+	JobID string
+}
+
+// PDFToImagesOptions represents options for rendering PDF pages to images.
+type PDFToImagesOptions struct {
+	// Format is the image format of each page (png, jpeg, webp). Defaults to png.
+	Format Format `json:"format,omitempty"`
+	// Quality is the image quality (0-100), applicable for JPEG and WebP.
+	Quality int `json:"quality,omitempty"`
+	// DPI is the render resolution. Defaults to 96. Ignored if Width is set.
+	DPI int `json:"dpi,omitempty"`
+	// Width scales each page to the given width in pixels, keeping its aspect ratio.
+	Width int `json:"width,omitempty"`
+	// PageRanges specifies which pages to render (e.g., "1-5, 8, 11-13").
+	PageRanges string `json:"pageRanges,omitempty"`
+}
+
+// PDFPageImage is a single rendered PDF page.
+type PDFPageImage struct {
+	// Page is the 1-based page number in the source document.
+	Page int `json:"page"`
+	// Data is the encoded image.
+	Data []byte `json:"image"`
+	// ContentType is the MIME type of Data.
+	ContentType string `json:"contentType,omitempty"`
+	// Width is the image width in pixels.
+	Width int `json:"width,omitempty"`
+	// Height is the image height in pixels.
+	Height int `json:"height,omitempty"`
+}
+
+// PDFImagesResult represents the result of a PDF-to-image conversion.
+type PDFImagesResult struct {
+	RetryInfo
+
+	// Pages contains one image per rendered page, in page order.
+	Pages []PDFPageImage
+}
+
+// pdfImagesResponse is the API response for a PDF-to-image request.
+type pdfImagesResponse struct {
+	APIResponse
+	Data *struct {
+		Pages []PDFPageImage `json:"pages"`
+	} `json:"data,omitempty"`
+}
+
+// PDFToImages renders the pages of a PDF document to images, one per page.
+//
+// The source is either raw PDF bytes, for example a PDFResult's Data, or the
+// job ID of a completed async PDF.
+//
+// Example:
+//
+//	pages, err := client.PDFToImages(ctx, screencraft.PDFSource{Data: pdf.Data},
+//	    &screencraft.PDFToImagesOptions{Width: 320})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, page := range pages.Pages {
+//	    os.WriteFile(fmt.Sprintf("page-%d.png", page.Page), page.Data, 0644)
+//	}
+func (c *Client) PDFToImages(ctx context.Context, source PDFSource, opts *PDFToImagesOptions) (*PDFImagesResult, error) {
+	if err := ValidatePDFToImagesOptions(source, opts); err != nil {
+		return nil, err
+	}
+
+	reqBody := map[string]interface{}{}
+	if len(source.Data) > 0 {
+		reqBody["pdf"] = source.Data
+	}
+	if source.JobID != "" {
+		reqBody["jobId"] = source.JobID
+	}
+	if opts != nil {
+		if opts.Format != "" {
+			reqBody["format"] = opts.Format
+		}
+		if opts.Quality > 0 {
+			reqBody["quality"] = opts.Quality
+		}
+		if opts.DPI > 0 {
+			reqBody["dpi"] = opts.DPI
+		}
+		if opts.Width > 0 {
+			reqBody["width"] = opts.Width
+		}
+		if opts.PageRanges != "" {
+			reqBody["pageRanges"] = opts.PageRanges
+		}
+	}
+
+	resp, info, err := c.doRequest(ctx, http.MethodPost, pdfImagesEndpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var imagesResp pdfImagesResponse
+	if err := c.decodeJSON(body, &imagesResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !imagesResp.Success || imagesResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    imagesResp.Message,
+		}
+	}
+
+	format := FormatPNG
+	if opts != nil && opts.Format != "" {
+		format = opts.Format
+	}
+	pages := imagesResp.Data.Pages
+	for i := range pages {
+		if pages[i].ContentType == "" {
+			pages[i].ContentType = format.ContentType()
+		}
+	}
+
+	return &PDFImagesResult{
+		RetryInfo: info,
+		Pages:     pages,
+	}, nil
+}
+
+// ValidatePDFToImagesOptions validates a PDF-to-image request.
+func ValidatePDFToImagesOptions(source PDFSource, opts *PDFToImagesOptions) error {
+	if len(source.Data) == 0 && source.JobID == "" {
+		return ErrMissingPDF
+	}
+
+	if len(source.Data) > 0 && source.JobID != "" {
+		return NewValidationError("jobId", "cannot be combined with pdf data", "exclusive").Error
+	}
+
+	if opts == nil {
+		return nil
+	}
+
+	if opts.Format != "" && !opts.Format.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidFormat, opts.Format)
+	}
+
+	if opts.Quality < 0 || opts.Quality > 100 {
+		return ErrInvalidQuality
+	}
+
+	if opts.DPI < 0 {
+		return NewValidationError("dpi", "must not be negative", "range").Error
+	}
+
+	if opts.Width < 0 {
+		return NewValidationError("width", "must not be negative", "range").Error
+	}
+
+	return nil
+}
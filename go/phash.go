@@ -0,0 +1,120 @@
+package screencraft
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/DancingTedDanson011/screencraft-go/internal/imageutil"
+)
+
+const (
+	// phashSize is the side of the grayscale thumbnail transformed by PHash.
+	phashSize = 32
+
+	// phashBits is the side of the block of low frequencies kept by PHash.
+	phashBits = 8
+)
+
+// ImageHash is a 64-bit perceptual image hash. Visually similar images have
+// hashes with a small Hamming distance.
+type ImageHash uint64
+
+// Distance returns the number of differing bits between h and other, from 0
+// (near-identical) to 64. Captures of the same page typically differ by
+// fewer than 10 bits.
+func (h ImageHash) Distance(other ImageHash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// String returns the hash as 16 hexadecimal digits.
+func (h ImageHash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// PHash computes a DCT-based perceptual hash of img. The image is reduced to
+// a 32x32 grayscale thumbnail, and each bit records whether one of the 64
+// lowest non-DC frequencies of its discrete cosine transform is above the
+// median, so the hash is robust to scaling, compression and small rendering
+// differences.
+//
+// Example:
+//
+//	seen := map[screencraft.ImageHash]bool{}
+//	hash := screencraft.PHash(img)
+//	if seen[hash] {
+//	    return // duplicate capture
+//	}
+//	seen[hash] = true
+func PHash(img image.Image) ImageHash {
+	small := imageutil.Resize(img, phashSize, phashSize)
+
+	var pixels [phashSize][phashSize]float64
+	for y := 0; y < phashSize; y++ {
+		for x := 0; x < phashSize; x++ {
+			r, g, b, _ := small.At(x, y).RGBA()
+			pixels[y][x] = (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 0xffff
+		}
+	}
+
+	coeffs := dctLowFrequencies(&pixels)
+
+	// Exclude the DC term, which only reflects average brightness
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash ImageHash
+	for i, c := range coeffs {
+		if i > 0 && c > median {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+// PHash computes the perceptual hash of the screenshot. See PHash.
+func (r *ScreenshotResult) PHash() (ImageHash, error) {
+	img, err := r.Image()
+	if err != nil {
+		return 0, err
+	}
+	return PHash(img), nil
+}
+
+// dctLowFrequencies returns the top-left phashBits x phashBits coefficients
+// of the 2D DCT-II of pixels, in row-major order.
+func dctLowFrequencies(pixels *[phashSize][phashSize]float64) []float64 {
+	var cos [phashBits][phashSize]float64
+	for u := 0; u < phashBits; u++ {
+		for x := 0; x < phashSize; x++ {
+			cos[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * phashSize))
+		}
+	}
+
+	// Transform rows, then columns
+	var rows [phashSize][phashBits]float64
+	for y := 0; y < phashSize; y++ {
+		for u := 0; u < phashBits; u++ {
+			var sum float64
+			for x := 0; x < phashSize; x++ {
+				sum += pixels[y][x] * cos[u][x]
+			}
+			rows[y][u] = sum
+		}
+	}
+
+	coeffs := make([]float64, 0, phashBits*phashBits)
+	for v := 0; v < phashBits; v++ {
+		for u := 0; u < phashBits; u++ {
+			var sum float64
+			for y := 0; y < phashSize; y++ {
+				sum += rows[y][u] * cos[v][y]
+			}
+			coeffs = append(coeffs, sum)
+		}
+	}
+	return coeffs
+}
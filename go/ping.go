@@ -0,0 +1,90 @@
+package screencraft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	healthEndpoint = "/health"
+)
+
+// PingResult represents the result of a health check.
+type PingResult struct {
+	RetryInfo
+
+	// Latency is the time from sending the request to reading the response,
+	// including any retries.
+	Latency time.Duration
+	// APIVersion is the version reported by the API.
+	APIVersion string
+	// Status is the status reported by the API, e.g. "ok".
+	Status string
+}
+
+// pingResponse is the API response for a health check.
+type pingResponse struct {
+	APIResponse
+	Data *struct {
+		Status  string `json:"status,omitempty"`
+		Version string `json:"version,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// Ping checks that the API is reachable and accepts the client's API key.
+// It is cheap enough to call from readiness probes.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+//	defer cancel()
+//	result, err := client.Ping(ctx)
+//	if err != nil {
+//	    http.Error(w, "screencraft unavailable", http.StatusServiceUnavailable)
+//	    return
+//	}
+//	log.Printf("screencraft %s: %s", result.APIVersion, result.Latency)
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	start := time.Now()
+
+	resp, info, err := c.doRequest(ctx, http.MethodGet, healthEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+	latency := time.Since(start)
+
+	var pingResp pingResponse
+	if err := c.decodeJSON(body, &pingResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !pingResp.Success {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    pingResp.Message,
+		}
+	}
+
+	result := &PingResult{
+		RetryInfo:  info,
+		Latency:    latency,
+		APIVersion: resp.Header.Get(apiVersionHeader),
+	}
+	if pingResp.Data != nil {
+		result.Status = pingResp.Data.Status
+		if result.APIVersion == "" {
+			result.APIVersion = pingResp.Data.Version
+		}
+	}
+
+	return result, nil
+}
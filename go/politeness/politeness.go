@@ -0,0 +1,276 @@
+// Package politeness paces batch captures so large jobs do not overload
+// third-party sites.
+//
+// A Policy fetches and honors each target host's robots.txt (including
+// Crawl-delay) and spaces out captures of the same host. It plugs into the
+// client with screencraft.WithPoliteness.
+//
+// Basic usage:
+//
+//	policy := politeness.New(&politeness.Options{
+//	    MinDelay: 2 * time.Second,
+//	})
+//	client := screencraft.New(apiKey, screencraft.WithPoliteness(policy))
+//
+//	for r := range client.ScreenshotBatchStream(ctx, items) {
+//	    if errors.Is(r.Err, politeness.ErrDisallowed) {
+//	        continue // excluded by robots.txt
+//	    }
+//	    // ...
+//	}
+package politeness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+)
+
+const (
+	// DefaultUserAgent is the user agent matched against robots.txt groups.
+	DefaultUserAgent = "screencraft-go"
+
+	// DefaultRobotsTTL is how long a fetched robots.txt is cached.
+	DefaultRobotsTTL = time.Hour
+
+	// DefaultMaxCrawlDelay caps the Crawl-delay honored for a host.
+	DefaultMaxCrawlDelay = time.Minute
+
+	// unreachableTTL is how long an unreachable robots.txt disallows a host
+	// before it is fetched again.
+	unreachableTTL = time.Minute
+
+	// maxRobotsSize is the maximum robots.txt size parsed (RFC 9309).
+	maxRobotsSize = 500 * 1024
+)
+
+// ErrDisallowed is returned for targets excluded by the host's robots.txt.
+var ErrDisallowed = errors.New("politeness: disallowed by robots.txt")
+
+// Options configures a Policy.
+type Options struct {
+	// UserAgent is the user agent matched against robots.txt groups.
+	// Defaults to DefaultUserAgent.
+	UserAgent string
+
+	// MinDelay is the minimum time between captures of the same host. The
+	// host's Crawl-delay is used if larger.
+	MinDelay time.Duration
+
+	// MaxCrawlDelay caps the Crawl-delay honored for a host. Defaults to
+	// DefaultMaxCrawlDelay.
+	MaxCrawlDelay time.Duration
+
+	// IgnoreRobots disables robots.txt checks, keeping only the per-host delay.
+	IgnoreRobots bool
+
+	// RobotsTTL is how long a fetched robots.txt is cached. Defaults to
+	// DefaultRobotsTTL.
+	RobotsTTL time.Duration
+
+	// HTTPClient fetches robots.txt files. Defaults to a client with a
+	// 10 second timeout.
+	HTTPClient *http.Client
+}
+
+// Policy enforces robots.txt rules and per-host pacing. It implements
+// screencraft.Politeness and is safe for concurrent use.
+type Policy struct {
+	opts Options
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState is the politeness state of a single host.
+type hostState struct {
+	// mu serializes robots.txt fetches for the host.
+	mu            sync.Mutex
+	robots        *robots
+	robotsExpires time.Time
+
+	// next is the earliest time the next capture may start. It is guarded
+	// by Policy.mu.
+	next time.Time
+}
+
+var _ screencraft.Politeness = (*Policy)(nil)
+
+// New creates a politeness policy. opts may be nil.
+func New(opts *Options) *Policy {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.UserAgent == "" {
+		o.UserAgent = DefaultUserAgent
+	}
+	if o.MaxCrawlDelay <= 0 {
+		o.MaxCrawlDelay = DefaultMaxCrawlDelay
+	}
+	if o.RobotsTTL <= 0 {
+		o.RobotsTTL = DefaultRobotsTTL
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Policy{
+		opts:  o,
+		hosts: make(map[string]*hostState),
+	}
+}
+
+// Wait blocks until targetURL may be captured. It returns an error wrapping
+// ErrDisallowed if the host's robots.txt excludes the URL, or the context
+// error if ctx is done first.
+func (p *Policy) Wait(ctx context.Context, targetURL string) error {
+	u, err := parseTarget(targetURL)
+	if err != nil {
+		return err
+	}
+
+	host := p.host(u.Scheme + "://" + u.Host)
+
+	delay := p.opts.MinDelay
+	if !p.opts.IgnoreRobots {
+		rules, err := p.robotsFor(ctx, u, host)
+		if err != nil {
+			return err
+		}
+		if !rules.allowed(u.RequestURI()) {
+			return fmt.Errorf("%w: %s", ErrDisallowed, targetURL)
+		}
+		crawlDelay := rules.crawlDelay
+		if crawlDelay > p.opts.MaxCrawlDelay {
+			crawlDelay = p.opts.MaxCrawlDelay
+		}
+		if crawlDelay > delay {
+			delay = crawlDelay
+		}
+	}
+
+	// Reserve the next slot for the host
+	p.mu.Lock()
+	now := time.Now()
+	start := host.next
+	if start.Before(now) {
+		start = now
+	}
+	host.next = start.Add(delay)
+	p.mu.Unlock()
+
+	wait := time.Until(start)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Allowed reports whether the host's robots.txt permits capturing targetURL.
+func (p *Policy) Allowed(ctx context.Context, targetURL string) (bool, error) {
+	u, err := parseTarget(targetURL)
+	if err != nil {
+		return false, err
+	}
+	if p.opts.IgnoreRobots {
+		return true, nil
+	}
+
+	host := p.host(u.Scheme + "://" + u.Host)
+	rules, err := p.robotsFor(ctx, u, host)
+	if err != nil {
+		return false, err
+	}
+	return rules.allowed(u.RequestURI()), nil
+}
+
+// parseTarget normalizes and parses a capture target URL.
+func parseTarget(targetURL string) (*url.URL, error) {
+	normalized, err := screencraft.NormalizeTargetURL(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("politeness: %w", err)
+	}
+	return url.Parse(normalized)
+}
+
+// host returns the state for the given origin, creating it if necessary.
+func (p *Policy) host(origin string) *hostState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	host, ok := p.hosts[origin]
+	if !ok {
+		host = &hostState{}
+		p.hosts[origin] = host
+	}
+	return host
+}
+
+// robotsFor returns the robots.txt rules for u's host, fetching them if the
+// cached copy is missing or expired. It only fails if ctx is done.
+func (p *Policy) robotsFor(ctx context.Context, u *url.URL, host *hostState) (*robots, error) {
+	host.mu.Lock()
+	defer host.mu.Unlock()
+
+	if host.robots != nil && time.Now().Before(host.robotsExpires) {
+		return host.robots, nil
+	}
+
+	rules, ttl := p.fetchRobots(ctx, u)
+	if err := ctx.Err(); err != nil {
+		// Do not cache a fetch aborted by the caller
+		return nil, err
+	}
+
+	host.robots = rules
+	host.robotsExpires = time.Now().Add(ttl)
+	return rules, nil
+}
+
+// fetchRobots fetches and parses robots.txt for u's host. Following RFC 9309,
+// a missing file (4xx) allows everything and an unreachable one (5xx or
+// network error) disallows everything; the latter is retried sooner.
+func (p *Policy) fetchRobots(ctx context.Context, u *url.URL) (*robots, time.Duration) {
+	robotsURL := u.Scheme + "://" + u.Host + "/robots.txt"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return disallowAll, unreachableTTL
+	}
+	req.Header.Set("User-Agent", p.opts.UserAgent)
+
+	resp, err := p.opts.HTTPClient.Do(req)
+	if err != nil {
+		return disallowAll, unreachableTTL
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return disallowAll, unreachableTTL
+	case resp.StatusCode >= 400:
+		return allowAll, p.opts.RobotsTTL
+	case resp.StatusCode >= 300:
+		// Redirects beyond the client's limit
+		return allowAll, p.opts.RobotsTTL
+	}
+
+	return parseRobots(io.LimitReader(resp.Body, maxRobotsSize), p.opts.UserAgent), p.opts.RobotsTTL
+}
@@ -0,0 +1,158 @@
+package politeness
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robots is the parsed robots.txt group that applies to our user agent.
+type robots struct {
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+// rule is a single Allow or Disallow line.
+type rule struct {
+	pattern string
+	allow   bool
+}
+
+// allowAll permits every path.
+var allowAll = &robots{}
+
+// disallowAll forbids every path.
+var disallowAll = &robots{rules: []rule{{pattern: "/", allow: false}}}
+
+// group is a robots.txt group with its user agents.
+type group struct {
+	agents     []string
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+// parseRobots parses a robots.txt file and returns the group that applies to
+// userAgent, following RFC 9309: the group with the longest matching
+// user-agent token wins, falling back to "*".
+func parseRobots(r io.Reader, userAgent string) *robots {
+	var groups []*group
+	var current *group
+	inAgents := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = &group{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+
+		case "allow", "disallow":
+			inAgents = false
+			if current == nil || (key == "disallow" && value == "") {
+				continue
+			}
+			current.rules = append(current.rules, rule{pattern: value, allow: key == "allow"})
+
+		case "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+
+	token := productToken(userAgent)
+	var best *group
+	bestLen := -1
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			switch {
+			case agent == "*" && bestLen < 0:
+				best, bestLen = g, 0
+			case agent != "*" && token != "" && strings.HasPrefix(token, agent) && len(agent) > bestLen:
+				best, bestLen = g, len(agent)
+			}
+		}
+	}
+
+	if best == nil {
+		return allowAll
+	}
+	return &robots{rules: best.rules, crawlDelay: best.crawlDelay}
+}
+
+// productToken returns the lowercase product name of a User-Agent string,
+// e.g. "screencraft-go" for "screencraft-go/1.0.0".
+func productToken(userAgent string) string {
+	token, _, _ := strings.Cut(strings.TrimSpace(userAgent), "/")
+	if i := strings.IndexByte(token, ' '); i >= 0 {
+		token = token[:i]
+	}
+	return strings.ToLower(token)
+}
+
+// allowed reports whether path may be crawled. The longest matching rule
+// wins; on a tie, Allow wins.
+func (r *robots) allowed(path string) bool {
+	allowed := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !matchPattern(rule.pattern, path) {
+			continue
+		}
+		if n := len(rule.pattern); n > longest || (n == longest && rule.allow) {
+			longest = n
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
+
+// matchPattern matches a robots.txt path pattern, supporting the '*'
+// wildcard and the '$' end anchor.
+func matchPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = strings.TrimSuffix(pattern, "$")
+	}
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		j := strings.Index(rest, part)
+		if j < 0 {
+			return false
+		}
+		rest = rest[j+len(part):]
+	}
+
+	return !anchored || rest == ""
+}
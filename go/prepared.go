@@ -0,0 +1,150 @@
+package screencraft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CurlAPIKeyEnv is the environment variable that stands in for the API key in
+// commands rendered by PreparedRequest.Curl.
+const CurlAPIKeyEnv = "SCREENCRAFT_API_KEY"
+
+// PreparedRequest is an API request as the client would send it.
+type PreparedRequest struct {
+	// Method is the HTTP method.
+	Method string
+	// URL is the full request URL.
+	URL string
+	// Header contains the request headers, including authentication.
+	Header http.Header
+	// Body is the JSON request body.
+	Body []byte
+}
+
+// BuildScreenshotRequest returns the request Screenshot would send for opts,
+// without sending it. Use it to test option mapping or to debug
+// disagreements with the API. Request middleware is not applied.
+//
+// Example:
+//
+//	req, err := client.BuildScreenshotRequest(&screencraft.ScreenshotOptions{
+//	    URL:      "https://example.com",
+//	    FullPage: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(string(req.Body))
+func (c *Client) BuildScreenshotRequest(opts *ScreenshotOptions) (*PreparedRequest, error) {
+	if err := ValidateScreenshotOptions(opts); err != nil {
+		return nil, err
+	}
+	return c.prepareRequest(http.MethodPost, screenshotEndpoint, c.buildScreenshotRequest(opts))
+}
+
+// BuildPDFRequest returns the request PDF would send for opts, without
+// sending it. Request middleware is not applied.
+func (c *Client) BuildPDFRequest(opts *PDFOptions) (*PreparedRequest, error) {
+	if err := ValidatePDFOptions(opts); err != nil {
+		return nil, err
+	}
+	return c.prepareRequest(http.MethodPost, pdfEndpoint, c.buildPDFRequest(opts))
+}
+
+// prepareRequest builds a request the way doRequest does, without sending it.
+func (c *Client) prepareRequest(method, endpoint string, body interface{}) (*PreparedRequest, error) {
+	apiKey, err := c.currentAPIKey(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to marshal request body: %w", err)
+	}
+
+	url := c.versionedBaseURL(c.BaseURL()) + c.endpointPath(endpoint)
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to create request: %w", err)
+	}
+	c.setRequestHeaders(req, apiKey)
+
+	return &PreparedRequest{
+		Method: method,
+		URL:    req.URL.String(),
+		Header: req.Header,
+		Body:   jsonBody,
+	}, nil
+}
+
+// Curl renders the request as a copy-pasteable curl command. The API key is
+// replaced by a reference to the SCREENCRAFT_API_KEY environment variable,
+// so the command can be shared with support.
+//
+// Example:
+//
+//	req, _ := client.BuildScreenshotRequest(opts)
+//	fmt.Println(req.Curl())
+func (r *PreparedRequest) Curl() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "curl -X %s %s", r.Method, curlURL(r.URL))
+
+	names := make([]string, 0, len(r.Header))
+	for name := range r.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range r.Header[name] {
+			b.WriteString(" \\\n  -H ")
+			switch {
+			case name == "Authorization" && strings.HasPrefix(value, "Bearer "):
+				fmt.Fprintf(&b, `"Authorization: Bearer $%s"`, CurlAPIKeyEnv)
+			case name == http.CanonicalHeaderKey(enterpriseAPIKeyHeader):
+				fmt.Fprintf(&b, `"%s: $%s"`, enterpriseAPIKeyHeader, CurlAPIKeyEnv)
+			default:
+				b.WriteString(shellQuote(name + ": " + value))
+			}
+		}
+	}
+
+	if len(r.Body) > 0 {
+		b.WriteString(" \\\n  --data-raw ")
+		b.WriteString(shellQuote(string(r.Body)))
+	}
+
+	return b.String()
+}
+
+// curlURL quotes a request URL for a curl command, replacing an API key
+// query parameter with a reference to CurlAPIKeyEnv.
+func curlURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.Query().Has(apiKeyQueryParam) {
+		return shellQuote(rawURL)
+	}
+
+	query := u.Query()
+	query.Del(apiKeyQueryParam)
+	u.RawQuery = query.Encode()
+	sep := "?"
+	if u.RawQuery != "" {
+		sep = "&"
+	}
+	return shellQuote(u.String()+sep+apiKeyQueryParam+"=") + `"$` + CurlAPIKeyEnv + `"`
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
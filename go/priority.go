@@ -0,0 +1,178 @@
+package screencraft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Priority represents the scheduling priority of a request.
+type Priority int
+
+const (
+	// PriorityLow is for background work such as archival captures.
+	PriorityLow Priority = iota - 1
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh is for interactive, user-facing captures.
+	PriorityHigh
+)
+
+// String returns the priority name.
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+// IsValid reports whether p is a supported priority.
+func (p Priority) IsValid() bool {
+	return p >= PriorityLow && p <= PriorityHigh
+}
+
+// MarshalJSON encodes the priority as its name.
+func (p Priority) MarshalJSON() ([]byte, error) {
+	if !p.IsValid() {
+		return nil, fmt.Errorf("screencraft: invalid priority %d", int(p))
+	}
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON decodes a priority name.
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for _, v := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+		if v.String() == name {
+			*p = v
+			return nil
+		}
+	}
+	return fmt.Errorf("screencraft: unknown priority %q", name)
+}
+
+// priorityLevels is the number of distinct priorities.
+const priorityLevels = 3
+
+// scheduler is a counting semaphore that grants slots to waiters in priority
+// order, FIFO within a priority. A capacity of zero or less means unlimited.
+type scheduler struct {
+	mu       sync.Mutex
+	capacity int
+	inUse    int
+	waiters  [priorityLevels][]chan struct{}
+}
+
+// newScheduler creates a scheduler with the given capacity.
+func newScheduler(capacity int) *scheduler {
+	return &scheduler{capacity: capacity}
+}
+
+// setCapacity changes the number of slots, waking waiters that fit.
+func (s *scheduler) setCapacity(capacity int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.capacity = capacity
+	for level := priorityLevels - 1; level >= 0; level-- {
+		for len(s.waiters[level]) > 0 && (capacity <= 0 || s.inUse < capacity) {
+			close(s.waiters[level][0])
+			s.waiters[level] = s.waiters[level][1:]
+			s.inUse++
+		}
+	}
+}
+
+// acquire blocks until a slot is available or ctx is done. The returned
+// function releases the slot and must be called exactly once.
+func (s *scheduler) acquire(ctx context.Context, p Priority) (func(), error) {
+	s.mu.Lock()
+	if s.capacity <= 0 {
+		s.mu.Unlock()
+		return func() {}, nil
+	}
+
+	if s.inUse < s.capacity && s.queued() == 0 {
+		s.inUse++
+		s.mu.Unlock()
+		return s.release, nil
+	}
+
+	level := priorityLevel(p)
+	ch := make(chan struct{})
+	s.waiters[level] = append(s.waiters[level], ch)
+	s.mu.Unlock()
+
+	select {
+	case <-ch:
+		return s.release, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		removed := s.remove(level, ch)
+		s.mu.Unlock()
+		if !removed {
+			// The slot was granted concurrently; pass it on.
+			s.release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release returns a slot, handing it to the highest-priority waiter if any.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for level := priorityLevels - 1; level >= 0; level-- {
+		if len(s.waiters[level]) > 0 && s.inUse <= s.capacity {
+			ch := s.waiters[level][0]
+			s.waiters[level] = s.waiters[level][1:]
+			close(ch)
+			return
+		}
+	}
+
+	if s.inUse > 0 {
+		s.inUse--
+	}
+}
+
+// queued returns the number of waiters. Callers must hold s.mu.
+func (s *scheduler) queued() int {
+	n := 0
+	for _, w := range s.waiters {
+		n += len(w)
+	}
+	return n
+}
+
+// remove removes ch from the waiters at level. Callers must hold s.mu.
+func (s *scheduler) remove(level int, ch chan struct{}) bool {
+	for i, w := range s.waiters[level] {
+		if w == ch {
+			s.waiters[level] = append(s.waiters[level][:i], s.waiters[level][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// priorityLevel maps a priority to a waiter queue index.
+func priorityLevel(p Priority) int {
+	switch {
+	case p <= PriorityLow:
+		return 0
+	case p >= PriorityHigh:
+		return 2
+	}
+	return 1
+}
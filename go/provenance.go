@@ -0,0 +1,166 @@
+package screencraft
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SignatureAlgorithmEd25519 identifies Ed25519 result signatures.
+const SignatureAlgorithmEd25519 = "ed25519"
+
+var (
+	// ErrResultSignatureMismatch is returned when a result does not match its
+	// provenance signature.
+	ErrResultSignatureMismatch = errors.New("screencraft: result does not match signature")
+
+	// ErrUnsupportedResult is returned for values that are not capture results.
+	ErrUnsupportedResult = errors.New("screencraft: unsupported result type")
+)
+
+// Provenance is the capture metadata attested by a result signature.
+type Provenance struct {
+	// URL is the captured URL.
+	URL string `json:"url"`
+	// CapturedAt is when the capture was received from the API.
+	CapturedAt time.Time `json:"capturedAt"`
+	// OptionsHash is the fingerprint of the capture options (see OptionsFingerprint).
+	OptionsHash string `json:"optionsHash"`
+	// ContentType is the MIME type of the artifact.
+	ContentType string `json:"contentType"`
+	// ContentHash is the hex-encoded SHA-256 digest of the artifact bytes.
+	ContentHash string `json:"contentHash"`
+}
+
+// ResultSignature is a detached signature over a capture artifact and its
+// provenance. It marshals to JSON for storage next to the artifact.
+type ResultSignature struct {
+	Provenance
+
+	// Algorithm is the signature algorithm.
+	Algorithm string `json:"algorithm"`
+	// Signature is the signature over the canonical provenance encoding.
+	Signature []byte `json:"signature"`
+}
+
+// SignResult creates a detached signature proving that a screenshot or PDF
+// was not altered after capture. result must be a *ScreenshotResult or
+// *PDFResult.
+//
+// Example:
+//
+//	sig, err := screencraft.SignResult(result, privateKey)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	sigJSON, _ := json.Marshal(sig)
+//	os.WriteFile("capture.png.sig", sigJSON, 0644)
+func SignResult(result interface{}, key ed25519.PrivateKey) (*ResultSignature, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("screencraft: invalid Ed25519 private key")
+	}
+
+	provenance, err := provenanceOf(result)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := provenance.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResultSignature{
+		Provenance: provenance,
+		Algorithm:  SignatureAlgorithmEd25519,
+		Signature:  ed25519.Sign(key, payload),
+	}, nil
+}
+
+// VerifyResult verifies that result matches sig and that sig was made with
+// the private key belonging to key. It returns ErrResultSignatureMismatch if
+// the artifact or its metadata were altered.
+//
+// The result's CapturedAt and OptionsHash are only compared if set, so a
+// result rebuilt from stored bytes (Data, URL and ContentType) verifies too.
+func VerifyResult(result interface{}, sig *ResultSignature, key ed25519.PublicKey) error {
+	if sig == nil {
+		return ErrResultSignatureMismatch
+	}
+	if sig.Algorithm != SignatureAlgorithmEd25519 {
+		return fmt.Errorf("screencraft: unsupported signature algorithm %q", sig.Algorithm)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return errors.New("screencraft: invalid Ed25519 public key")
+	}
+
+	actual, err := provenanceOf(result)
+	if err != nil {
+		return err
+	}
+
+	if actual.ContentHash != sig.ContentHash ||
+		actual.URL != sig.URL ||
+		actual.ContentType != sig.ContentType ||
+		(!actual.CapturedAt.IsZero() && !actual.CapturedAt.Equal(sig.CapturedAt)) ||
+		(actual.OptionsHash != "" && actual.OptionsHash != sig.OptionsHash) {
+		return ErrResultSignatureMismatch
+	}
+
+	payload, err := sig.Provenance.canonical()
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(key, payload, sig.Signature) {
+		return ErrResultSignatureMismatch
+	}
+
+	return nil
+}
+
+// provenanceOf returns the provenance of a capture result.
+func provenanceOf(result interface{}) (Provenance, error) {
+	var p Provenance
+	var data []byte
+
+	switch r := result.(type) {
+	case *ScreenshotResult:
+		if r == nil {
+			return p, ErrUnsupportedResult
+		}
+		data = r.Data
+		p = Provenance{URL: r.URL, CapturedAt: r.CapturedAt, OptionsHash: r.OptionsHash, ContentType: r.ContentType}
+	case *PDFResult:
+		if r == nil {
+			return p, ErrUnsupportedResult
+		}
+		data = r.Data
+		p = Provenance{URL: r.URL, CapturedAt: r.CapturedAt, OptionsHash: r.OptionsHash, ContentType: r.ContentType}
+	default:
+		return p, ErrUnsupportedResult
+	}
+
+	sum := sha256.Sum256(data)
+	p.ContentHash = hex.EncodeToString(sum[:])
+	return p, nil
+}
+
+// canonical returns the signed encoding of the provenance: its JSON form with
+// the timestamp in UTC.
+func (p Provenance) canonical() ([]byte, error) {
+	p.CapturedAt = p.CapturedAt.UTC()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(p); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to encode provenance: %w", err)
+	}
+	return buf.Bytes(), nil
+}
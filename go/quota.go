@@ -0,0 +1,198 @@
+package screencraft
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCreditsInterval is how often credit pacing refreshes the remaining
+// credits by default.
+const DefaultCreditsInterval = time.Minute
+
+// QuotaKind identifies the quota a QuotaEvent is about.
+type QuotaKind string
+
+const (
+	// QuotaRequests is the API rate limit window (see RateLimitInfo).
+	QuotaRequests QuotaKind = "requests"
+	// QuotaCredits is the billing period's credits (see Usage).
+	QuotaCredits QuotaKind = "credits"
+)
+
+// QuotaEvent reports that a quota fell below its threshold.
+type QuotaEvent struct {
+	// Kind is the quota that is running low.
+	Kind QuotaKind
+	// Remaining is what is left of the quota.
+	Remaining int
+	// Threshold is the configured threshold Remaining fell below.
+	Threshold int
+	// Reset is when the quota resets.
+	Reset time.Time
+}
+
+// QuotaPolicy configures WithQuotaPacing.
+type QuotaPolicy struct {
+	// MinRemaining is the number of requests left in the rate limit window
+	// (RateLimitInfo.Remaining) below which requests are paced. Zero
+	// disables pacing on the rate limit.
+	MinRemaining int
+
+	// MinCredits is the number of credits left in the billing period
+	// (Usage.CreditsRemaining) below which requests are paced. Zero disables
+	// pacing on credits.
+	MinCredits int
+
+	// CreditsInterval is how often the remaining credits are refreshed with
+	// Client.Usage while MinCredits is set (default: DefaultCreditsInterval).
+	CreditsInterval time.Duration
+
+	// Pause makes requests wait for the quota to reset instead of spreading
+	// what is left evenly over the time until the reset. Waits are only
+	// bounded by the request's context, and credits reset with the billing
+	// period, so use OnLow to cancel work that cannot wait that long.
+	Pause bool
+
+	// OnLow, if set, is called when a quota falls below its threshold. It is
+	// called again only after the quota has recovered and fallen below the
+	// threshold once more.
+	OnLow func(QuotaEvent)
+}
+
+// quotaPacer tracks the state of quota pacing.
+type quotaPacer struct {
+	policy QuotaPolicy
+
+	mu sync.Mutex
+	// low records which quotas are below their thresholds.
+	low map[QuotaKind]bool
+	// credits and periodEnd are the last known remaining credits and when
+	// they reset, if creditsKnown.
+	credits      int
+	periodEnd    time.Time
+	creditsKnown bool
+	// checked is when credits were last refreshed.
+	checked time.Time
+	// refreshing is set while a refresh is in flight.
+	refreshing bool
+}
+
+// WithQuotaPacing slows down or pauses requests when the rate limit window
+// or the billing period's credits run low, so a large batch does not
+// exhaust the quota needed by the rest of the application. Below a
+// threshold, requests are spread evenly over the time until the quota
+// resets, or, with Pause, held until it resets. OnLow is notified either
+// way.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithQuotaPacing(screencraft.QuotaPolicy{
+//	    MinCredits: 500,
+//	    Pause:      true,
+//	    OnLow: func(e screencraft.QuotaEvent) {
+//	        log.Printf("%s quota low: %d left until %s", e.Kind, e.Remaining, e.Reset)
+//	    },
+//	}))
+func WithQuotaPacing(policy QuotaPolicy) Option {
+	return func(c *Client) {
+		if policy.MinRemaining <= 0 && policy.MinCredits <= 0 {
+			c.quota = nil
+			return
+		}
+		if policy.CreditsInterval <= 0 {
+			policy.CreditsInterval = DefaultCreditsInterval
+		}
+		c.quota = &quotaPacer{policy: policy, low: make(map[QuotaKind]bool)}
+	}
+}
+
+// paceQuota waits as long as quota pacing requires, or until ctx is done.
+func (c *Client) paceQuota(ctx context.Context) error {
+	p := c.quota
+	if p == nil || callOptionsFrom(ctx).skipQuotaPacing {
+		return nil
+	}
+
+	now := c.now()
+	var delay time.Duration
+	if p.policy.MinRemaining > 0 {
+		if info := c.GetRateLimitInfo(); info != nil {
+			delay = max(delay, p.check(QuotaRequests, info.Remaining, p.policy.MinRemaining, info.Reset, now))
+		}
+	}
+	if p.policy.MinCredits > 0 {
+		if credits, periodEnd, ok := c.remainingCredits(ctx, now); ok {
+			delay = max(delay, p.check(QuotaCredits, credits, p.policy.MinCredits, periodEnd, now))
+		}
+	}
+	if delay <= 0 {
+		return nil
+	}
+	c.logf("Quota running low, waiting %s", delay)
+
+	return c.sleep(ctx, delay)
+}
+
+// check returns how long to wait before the next request given what is left
+// of a quota, and notifies OnLow when the quota falls below its threshold.
+func (p *quotaPacer) check(kind QuotaKind, remaining, threshold int, reset, now time.Time) time.Duration {
+	low := remaining < threshold && reset.After(now)
+
+	p.mu.Lock()
+	crossed := low && !p.low[kind]
+	p.low[kind] = low
+	p.mu.Unlock()
+
+	if crossed && p.policy.OnLow != nil {
+		p.policy.OnLow(QuotaEvent{Kind: kind, Remaining: remaining, Threshold: threshold, Reset: reset})
+	}
+	if !low {
+		return 0
+	}
+
+	untilReset := reset.Sub(now)
+	if p.policy.Pause || remaining <= 0 {
+		return untilReset
+	}
+	return untilReset / time.Duration(remaining+1)
+}
+
+// remainingCredits returns the last known remaining credits and when they
+// reset, refreshing them first if they are older than the policy's
+// CreditsInterval. Concurrent callers use the previous value while a refresh
+// is in flight.
+func (c *Client) remainingCredits(ctx context.Context, now time.Time) (int, time.Time, bool) {
+	p := c.quota
+
+	p.mu.Lock()
+	refresh := !p.refreshing && now.Sub(p.checked) >= p.policy.CreditsInterval
+	if refresh {
+		p.refreshing = true
+	}
+	credits, periodEnd, ok := p.credits, p.periodEnd, p.creditsKnown
+	p.mu.Unlock()
+
+	if !refresh {
+		return credits, periodEnd, ok
+	}
+
+	// The refresh is not paced itself and does not inherit the call's
+	// headers or idempotency key
+	usageCtx := context.WithValue(ctx, callOptionsKey{}, callOptions{
+		priority:        callOptionsFrom(ctx).priority,
+		skipQuotaPacing: true,
+	})
+	usage, err := c.Usage(usageCtx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refreshing = false
+	p.checked = now
+	if err != nil {
+		c.logf("Failed to refresh remaining credits: %v", err)
+		return p.credits, p.periodEnd, p.creditsKnown
+	}
+	p.credits, p.periodEnd, p.creditsKnown = usage.CreditsRemaining, usage.PeriodEnd, true
+	return p.credits, p.periodEnd, true
+}
@@ -0,0 +1,138 @@
+package screencraft
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the client to rps requests per second with bursts of
+// up to burst requests, using a token bucket. Requests, including retries,
+// wait for a token before they are sent, so bulk jobs stay below the API's
+// rate limit instead of relying on 429 retries. A non-positive rps disables
+// the limit.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithRateLimit(5, 10))
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.rateLimiter = nil
+			return
+		}
+		c.rateLimiter = newTokenBucket(rps, burst)
+	}
+}
+
+// adaptiveThrottleThreshold is the fraction of the rate limit below which
+// adaptive throttling starts spacing out requests.
+const adaptiveThrottleThreshold = 0.1
+
+// WithAdaptiveThrottling makes the client slow down as the API's rate limit
+// runs out. Once X-RateLimit-Remaining drops below 10% of X-RateLimit-Limit,
+// requests are spread evenly over the time left until X-RateLimit-Reset;
+// when no requests remain, they wait for the reset. Waits are capped like
+// Retry-After waits (see WithMaxRetryAfter).
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithAdaptiveThrottling(true))
+func WithAdaptiveThrottling(enabled bool) Option {
+	return func(c *Client) {
+		c.adaptiveThrottling = enabled
+	}
+}
+
+// throttle waits as long as adaptive throttling requires, or until ctx is
+// done.
+func (c *Client) throttle(ctx context.Context) error {
+	if !c.adaptiveThrottling {
+		return nil
+	}
+
+	delay := throttleDelay(c.GetRateLimitInfo(), c.now())
+	if delay <= 0 {
+		return nil
+	}
+	if c.maxRetryAfter > 0 && delay > c.maxRetryAfter {
+		delay = c.maxRetryAfter
+	}
+	c.logf("Rate limit nearly exhausted, waiting %s", delay)
+
+	return c.sleep(ctx, delay)
+}
+
+// throttleDelay returns how long to wait before the next request given the
+// last rate limit information.
+func throttleDelay(info *RateLimitInfo, now time.Time) time.Duration {
+	if info == nil || info.Limit <= 0 || !info.Reset.After(now) {
+		return 0
+	}
+
+	untilReset := info.Reset.Sub(now)
+	if info.Remaining <= 0 {
+		return untilReset
+	}
+	if float64(info.Remaining) >= float64(info.Limit)*adaptiveThrottleThreshold {
+		return 0
+	}
+	return untilReset / time.Duration(info.Remaining+1)
+}
+
+// tokenBucket is a token bucket rate limiter. Waiters reserve tokens in
+// arrival order, so the bucket's balance may go negative while they sleep.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full bucket refilling at rate tokens per second.
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// wait blocks until a token is available or ctx is done, using the client's
+// clock.
+func (b *tokenBucket) wait(ctx context.Context, c *Client) error {
+	if err := c.sleep(ctx, b.reserve(c.now())); err != nil {
+		b.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.last.IsZero() {
+		b.last = now
+	}
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that will not be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,183 @@
+package screencraft
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces sensitive values in logs and transcripts.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are JSON keys whose values are always redacted.
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+	"apikey":        true,
+	"api_key":       true,
+	"authorization": true,
+	"cookie":        true,
+	"credentials":   true,
+}
+
+// sensitiveHeaders are HTTP headers whose values are always redacted.
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+}
+
+// sensitivePatterns match credentials embedded in free-form text such as log
+// messages and error strings. The first group is kept.
+var sensitivePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;]+`),
+	regexp.MustCompile(`(?i)((?:password|secret|token|api_?key|x-api-key)["']?\s*[:=]\s*["']?)[^\s"'&,}]+`),
+}
+
+// WithRedaction controls whether secrets are redacted from debug logs,
+// structured logs, transcripts and the Message and Details of API errors.
+// Redaction is on by default and covers the API key, URL signing secret,
+// Authorization and cookie headers, passwords and webhook secrets. Turn it
+// off only for local debugging.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithDebug(true),
+//	    screencraft.WithRedaction(false),
+//	)
+func WithRedaction(enabled bool) Option {
+	return func(c *Client) {
+		c.noRedaction = !enabled
+	}
+}
+
+// redactString redacts the client's own secrets and credential-like
+// key/value pairs from s.
+func (c *Client) redactString(s string) string {
+	if c.noRedaction || s == "" {
+		return s
+	}
+
+	c.mu.RLock()
+	secrets := []string{c.apiKey, c.previousAPIKey, c.credentialsKey, c.signingSecret}
+	c.mu.RUnlock()
+	if c.apiKeys != nil {
+		secrets = append(secrets, c.apiKeys.keys...)
+	}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	for _, re := range sensitivePatterns {
+		s = re.ReplaceAllString(s, "${1}"+redacted)
+	}
+	return s
+}
+
+// redactHeader returns h with sensitive values redacted, unless redaction is
+// disabled.
+func (c *Client) redactHeader(h http.Header) http.Header {
+	if c.noRedaction {
+		return h
+	}
+	return redactHeader(h)
+}
+
+// redactDetails returns a copy of API error details with sensitive values
+// redacted, unless redaction is disabled.
+func (c *Client) redactDetails(details map[string]interface{}) map[string]interface{} {
+	if c.noRedaction || details == nil {
+		return details
+	}
+	out, _ := redactValue("", details).(map[string]interface{})
+	for k, v := range out {
+		if s, ok := v.(string); ok {
+			out[k] = c.redactString(s)
+		}
+	}
+	return out
+}
+
+// redactHeader returns a copy of h with sensitive values redacted.
+func redactHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		if sensitiveHeaders[strings.ToLower(name)] {
+			out[name] = []string{redacted}
+			continue
+		}
+		out[name] = values
+	}
+	return out
+}
+
+// redactJSON returns data with sensitive values redacted, or data unchanged
+// if it is not JSON.
+func redactJSON(data []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+
+	out, err := json.Marshal(redactValue("", v))
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// redactValue redacts sensitive values in a decoded JSON value found under
+// key.
+func redactValue(key string, v interface{}) interface{} {
+	key = strings.ToLower(key)
+	if sensitiveKeys[key] {
+		return redacted
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if key == "headers" && sensitiveHeaders[strings.ToLower(k)] {
+				out[k] = redacted
+				continue
+			}
+			out[k] = redactValue(k, e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = redactValue("", e)
+			if key == "cookies" || key == "headers" {
+				redactNamedValue(out[i], key == "cookies")
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// redactNamedValue redacts the value of a {name, value} cookie or header
+// object. Cookie values are always redacted; header values only for
+// sensitive headers.
+func redactNamedValue(v interface{}, always bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if _, has := m["value"]; !has {
+		return
+	}
+	name, _ := m["name"].(string)
+	if always || sensitiveHeaders[strings.ToLower(name)] {
+		m["value"] = redacted
+	}
+}
@@ -0,0 +1,108 @@
+package screencraft
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/DancingTedDanson011/screencraft-go/internal/imageutil"
+)
+
+// defaultResizeQuality is the JPEG quality of locally resized images when
+// no quality was requested.
+const defaultResizeQuality = 90
+
+// applyResize resizes the images of a result locally if the API returned
+// them at their original size (e.g. on deployments without server-side
+// post-processing).
+func applyResize(result *ScreenshotResult, opts *ScreenshotOptions) error {
+	if len(result.Data) > 0 {
+		data, width, height, err := resizeImage(result.Data, opts)
+		if err != nil {
+			return err
+		}
+		result.Data = data
+		if width > 0 {
+			result.Width, result.Height = width, height
+		}
+	}
+
+	for format, img := range result.Images {
+		data, _, _, err := resizeImage(img, opts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", format, err)
+		}
+		result.Images[format] = data
+	}
+
+	return nil
+}
+
+// resizeImage resizes data according to opts.Resize unless it already has
+// the target size. It returns the image and its new dimensions, or zero
+// dimensions if it was left unchanged.
+func resizeImage(data []byte, opts *ScreenshotOptions) ([]byte, int, int, error) {
+	r := opts.Resize
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		// Formats the standard library cannot decode (WebP) are assumed to
+		// be resized by the API
+		return data, 0, 0, nil
+	}
+
+	mode := fitMode(r.Fit)
+	width, height := imageutil.FitSize(cfg.Width, cfg.Height, r.Width, r.Height, mode)
+	if cfg.Width == width && cfg.Height == height {
+		return data, 0, 0, nil
+	}
+
+	img, err := imageutil.Decode(data)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("screencraft: local resize failed: %w", err)
+	}
+	resized := imageutil.Fit(img, r.Width, r.Height, mode, resizeFilter(r.Algorithm))
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		quality := opts.Quality
+		if quality <= 0 {
+			quality = defaultResizeQuality
+		}
+		err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: quality})
+	default:
+		err = png.Encode(&buf, resized)
+	}
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("screencraft: local resize failed: %w", err)
+	}
+
+	return buf.Bytes(), resized.Bounds().Dx(), resized.Bounds().Dy(), nil
+}
+
+// fitMode maps a Fit to the local resize mode.
+func fitMode(fit Fit) imageutil.FitMode {
+	switch fit {
+	case FitContain:
+		return imageutil.FitContain
+	case FitFill:
+		return imageutil.FitFill
+	default:
+		return imageutil.FitCover
+	}
+}
+
+// resizeFilter maps a ResizeAlgorithm to the local resampling filter.
+func resizeFilter(algorithm ResizeAlgorithm) imageutil.Filter {
+	switch algorithm {
+	case ResizeBilinear:
+		return imageutil.Bilinear
+	case ResizeNearest:
+		return imageutil.NearestNeighbor
+	default:
+		return imageutil.Area
+	}
+}
@@ -0,0 +1,294 @@
+package screencraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultFS is an fs.FS backed by capture results, so they can be served
+// with http.FileServer, walked with fs.WalkDir or written into archives
+// without glue code. Names are slash-separated paths such as
+// "2024-06-01/home.png"; directories are implied by them. Files added with
+// AddLazyScreenshot or AddLazyPDF are captured on first access.
+//
+// A ResultFS is safe for concurrent use.
+//
+// Example:
+//
+//	results := screencraft.NewResultFS()
+//	results.AddScreenshot("home.png", home)
+//	results.AddLazyPDF("report.pdf", client, &screencraft.PDFOptions{URL: "https://example.com/report"})
+//	http.Handle("/captures/", http.StripPrefix("/captures/", http.FileServer(http.FS(results))))
+type ResultFS struct {
+	mu    sync.Mutex
+	files map[string]*resultFile
+}
+
+// resultFile is a file in a ResultFS.
+type resultFile struct {
+	mu      sync.Mutex
+	data    []byte
+	modTime time.Time
+	loaded  bool
+	load    func() ([]byte, time.Time, error)
+}
+
+// NewResultFS creates an empty ResultFS.
+func NewResultFS() *ResultFS {
+	return &ResultFS{files: make(map[string]*resultFile)}
+}
+
+// AddScreenshot adds the image of a screenshot result under name.
+func (f *ResultFS) AddScreenshot(name string, result *ScreenshotResult) error {
+	if result == nil {
+		return f.add(name, &resultFile{loaded: true})
+	}
+	return f.add(name, &resultFile{data: result.Data, modTime: result.CapturedAt, loaded: true})
+}
+
+// AddPDF adds the document of a PDF result under name.
+func (f *ResultFS) AddPDF(name string, result *PDFResult) error {
+	if result == nil {
+		return f.add(name, &resultFile{loaded: true})
+	}
+	return f.add(name, &resultFile{data: result.Data, modTime: result.CapturedAt, loaded: true})
+}
+
+// AddLazyScreenshot adds a screenshot under name that is captured with c the
+// first time the file is opened or stat'ed. Failed captures are retried on
+// the next access.
+func (f *ResultFS) AddLazyScreenshot(name string, c Capturer, opts *ScreenshotOptions) error {
+	return f.add(name, &resultFile{load: func() ([]byte, time.Time, error) {
+		result, err := c.Screenshot(context.Background(), opts)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return result.Data, result.CapturedAt, nil
+	}})
+}
+
+// AddLazyPDF adds a PDF under name that is generated with c the first time
+// the file is opened or stat'ed. Failed captures are retried on the next
+// access.
+func (f *ResultFS) AddLazyPDF(name string, c Capturer, opts *PDFOptions) error {
+	return f.add(name, &resultFile{load: func() ([]byte, time.Time, error) {
+		result, err := c.PDF(context.Background(), opts)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return result.Data, result.CapturedAt, nil
+	}})
+}
+
+// add registers a file under name.
+func (f *ResultFS) add(name string, file *resultFile) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "add", Path: name, Err: fs.ErrInvalid}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if _, ok := f.files[dir]; ok {
+			return &fs.PathError{Op: "add", Path: name, Err: fs.ErrExist}
+		}
+	}
+	if f.isDir(name) {
+		return &fs.PathError{Op: "add", Path: name, Err: fs.ErrExist}
+	}
+	f.files[name] = file
+	return nil
+}
+
+// isDir reports whether name is an implied directory. f.mu must be held.
+func (f *ResultFS) isDir(name string) bool {
+	if name == "." {
+		return true
+	}
+	prefix := name + "/"
+	for n := range f.files {
+		if strings.HasPrefix(n, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Open implements fs.FS.
+func (f *ResultFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	f.mu.Lock()
+	file, ok := f.files[name]
+	if !ok {
+		entries, isDir := f.readDir(name)
+		f.mu.Unlock()
+		if !isDir {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		}
+		return &resultDir{info: dirInfo{name: path.Base(name)}, entries: entries}, nil
+	}
+	f.mu.Unlock()
+
+	data, modTime, err := file.contents()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &openResultFile{
+		Reader: bytes.NewReader(data),
+		info:   fileInfo{name: path.Base(name), size: int64(len(data)), modTime: modTime},
+	}, nil
+}
+
+// readDir returns the entries of the directory name, and whether it exists.
+// f.mu must be held.
+func (f *ResultFS) readDir(name string) ([]fs.DirEntry, bool) {
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for n, file := range f.files {
+		if !strings.HasPrefix(n, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(n, prefix)
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			dir := rest[:i]
+			if !seen[dir] {
+				seen[dir] = true
+				entries = append(entries, fs.FileInfoToDirEntry(dirInfo{name: dir}))
+			}
+			continue
+		}
+		entries = append(entries, &lazyDirEntry{name: rest, file: file})
+	}
+	if len(entries) == 0 && name != "." {
+		return nil, false
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, true
+}
+
+// contents returns the file's data, capturing it first if necessary.
+func (file *resultFile) contents() ([]byte, time.Time, error) {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if !file.loaded {
+		data, modTime, err := file.load()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		file.data, file.modTime, file.loaded = data, modTime, true
+	}
+	return file.data, file.modTime, nil
+}
+
+// openResultFile is an open file of a ResultFS. It implements io.Seeker and
+// io.ReaderAt, as http.FileServer requires.
+type openResultFile struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+// Stat implements fs.File.
+func (o *openResultFile) Stat() (fs.FileInfo, error) { return o.info, nil }
+
+// Close implements fs.File.
+func (o *openResultFile) Close() error { return nil }
+
+// resultDir is an open directory of a ResultFS.
+type resultDir struct {
+	info    dirInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+// Stat implements fs.File.
+func (d *resultDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+// Read implements fs.File.
+func (d *resultDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+// Close implements fs.File.
+func (d *resultDir) Close() error { return nil }
+
+// ReadDir implements fs.ReadDirFile.
+func (d *resultDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
+
+// lazyDirEntry is a directory entry that only captures its file when Info
+// is called.
+type lazyDirEntry struct {
+	name string
+	file *resultFile
+}
+
+func (e *lazyDirEntry) Name() string      { return e.name }
+func (e *lazyDirEntry) IsDir() bool       { return false }
+func (e *lazyDirEntry) Type() fs.FileMode { return 0 }
+
+// Info implements fs.DirEntry, capturing the file if necessary.
+func (e *lazyDirEntry) Info() (fs.FileInfo, error) {
+	data, modTime, err := e.file.contents()
+	if err != nil {
+		return nil, err
+	}
+	return fileInfo{name: e.name, size: int64(len(data)), modTime: modTime}, nil
+}
+
+// fileInfo describes a file of a ResultFS.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return 0o444 }
+func (i fileInfo) ModTime() time.Time { return i.modTime }
+func (i fileInfo) IsDir() bool        { return false }
+func (i fileInfo) Sys() interface{}   { return nil }
+
+// dirInfo describes a directory of a ResultFS.
+type dirInfo struct {
+	name string
+}
+
+func (i dirInfo) Name() string       { return i.name }
+func (i dirInfo) Size() int64        { return 0 }
+func (i dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (i dirInfo) ModTime() time.Time { return time.Time{} }
+func (i dirInfo) IsDir() bool        { return true }
+func (i dirInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,224 @@
+package screencraft
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides whether and when failed requests are retried. The
+// client never makes more than WithMaxRetries retries, whatever the policy
+// says.
+type RetryPolicy interface {
+	// ShouldRetry reports whether a request should be retried after its
+	// attempt-th attempt (starting at 1) failed with err.
+	ShouldRetry(err error, attempt int) bool
+	// Backoff returns how long to wait before the attempt-th retry (starting
+	// at 1) after a failure with err.
+	Backoff(attempt int, err error) time.Duration
+}
+
+// WithRetryPolicy replaces the default exponential backoff policy. The
+// policy's wait bounds take precedence over WithRetryWait.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithRetryPolicy(&screencraft.LinearRetryPolicy{
+//	    Step:      2 * time.Second,
+//	    Max:       10 * time.Second,
+//	    Retryable: screencraft.RetryOnStatus(http.StatusBadGateway, http.StatusServiceUnavailable),
+//	}))
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithMaxRetryAfter caps how long the client waits when the API asks it to
+// retry later with a Retry-After header. Longer requests are clamped to max,
+// so a misbehaving header cannot block callers for hours. The default is
+// DefaultMaxRetryAfter; zero disables the cap.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithMaxRetryAfter(10*time.Second))
+func WithMaxRetryAfter(max time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetryAfter = max
+	}
+}
+
+// ExponentialRetryPolicy doubles the wait after each retry, up to Max, and
+// adds up to 25% jitter. Retry-After hints from the API take precedence. It
+// is the default policy, configured with WithRetryWait.
+type ExponentialRetryPolicy struct {
+	// Min is the wait before the first retry.
+	Min time.Duration
+	// Max is the maximum wait before jitter.
+	Max time.Duration
+	// Retryable classifies errors. Defaults to IsRetryable.
+	Retryable func(err error) bool
+	// Jitter returns a pseudo-random number in [0.0, 1.0). Defaults to the
+	// client's source (see WithRandSource).
+	Jitter func() float64
+}
+
+// ShouldRetry reports whether err is retryable.
+func (p *ExponentialRetryPolicy) ShouldRetry(err error, attempt int) bool {
+	return retryable(p.Retryable, err)
+}
+
+// Backoff returns the exponential wait before the attempt-th retry.
+func (p *ExponentialRetryPolicy) Backoff(attempt int, err error) time.Duration {
+	if retryAfter := GetRetryAfter(err); retryAfter > 0 {
+		return retryAfter
+	}
+
+	backoff := float64(p.Min) * math.Pow(2, float64(attempt-1))
+	if backoff > float64(p.Max) {
+		backoff = float64(p.Max)
+	}
+
+	// Add jitter (up to 25%)
+	random := rand.Float64
+	if p.Jitter != nil {
+		random = p.Jitter
+	}
+	jitter := backoff * 0.25 * random()
+	return time.Duration(backoff + jitter)
+}
+
+// LinearRetryPolicy increases the wait by Step after each retry, up to Max.
+// Retry-After hints from the API take precedence.
+type LinearRetryPolicy struct {
+	// Step is the wait before the first retry and the increase per retry.
+	Step time.Duration
+	// Max is the maximum wait. Zero means no maximum.
+	Max time.Duration
+	// Retryable classifies errors. Defaults to IsRetryable.
+	Retryable func(err error) bool
+}
+
+// ShouldRetry reports whether err is retryable.
+func (p *LinearRetryPolicy) ShouldRetry(err error, attempt int) bool {
+	return retryable(p.Retryable, err)
+}
+
+// Backoff returns the linear wait before the attempt-th retry.
+func (p *LinearRetryPolicy) Backoff(attempt int, err error) time.Duration {
+	if retryAfter := GetRetryAfter(err); retryAfter > 0 {
+		return retryAfter
+	}
+
+	backoff := p.Step * time.Duration(attempt)
+	if p.Max > 0 && backoff > p.Max {
+		backoff = p.Max
+	}
+	return backoff
+}
+
+// RetryOnStatus returns a classifier for RetryPolicy implementations that
+// retries API errors with one of the given HTTP status codes, as well as
+// network errors and timeouts.
+//
+// Example:
+//
+//	policy := &screencraft.ExponentialRetryPolicy{
+//	    Min:       time.Second,
+//	    Max:       30 * time.Second,
+//	    Retryable: screencraft.RetryOnStatus(http.StatusTooManyRequests, http.StatusServiceUnavailable),
+//	}
+func RetryOnStatus(codes ...int) func(err error) bool {
+	set := make(map[int]bool, len(codes))
+	for _, code := range codes {
+		set[code] = true
+	}
+
+	return func(err error) bool {
+		if IsNetworkError(err) || IsTimeoutError(err) {
+			return true
+		}
+		return set[errorStatusCode(err)]
+	}
+}
+
+// retryable classifies err with fn, or with IsRetryable if fn is nil.
+func retryable(fn func(err error) bool, err error) bool {
+	if fn != nil {
+		return fn(err)
+	}
+	return IsRetryable(err)
+}
+
+// errorStatusCode returns the HTTP status code of an API error, or 0.
+func errorStatusCode(err error) int {
+	var (
+		authErr       *AuthenticationError
+		rateErr       *RateLimitError
+		validationErr *ValidationError
+		serverErr     *ServerError
+		scErr         *Error
+	)
+	switch {
+	case errors.As(err, &authErr):
+		return authErr.StatusCode
+	case errors.As(err, &rateErr):
+		return rateErr.StatusCode
+	case errors.As(err, &validationErr):
+		return validationErr.StatusCode
+	case errors.As(err, &serverErr):
+		return serverErr.StatusCode
+	case errors.As(err, &scErr):
+		return scErr.StatusCode
+	}
+	return 0
+}
+
+// activeRetryPolicy returns the client's retry policy, or the default
+// exponential policy if none was set.
+func (c *Client) activeRetryPolicy() RetryPolicy {
+	if p, ok := c.retryPolicy.(*ExponentialRetryPolicy); ok && p.Jitter == nil && c.rand != nil {
+		policy := *p
+		policy.Jitter = c.jitter
+		return &policy
+	}
+	if c.retryPolicy != nil {
+		return c.retryPolicy
+	}
+	return &ExponentialRetryPolicy{Min: c.retryWaitMin, Max: c.retryWaitMax, Jitter: c.jitter}
+}
+
+// clampRetryAfter caps a backoff driven by a server Retry-After hint at the
+// client's maximum.
+func (c *Client) clampRetryAfter(wait time.Duration, err error) time.Duration {
+	if c.maxRetryAfter > 0 && wait > c.maxRetryAfter && GetRetryAfter(err) > 0 {
+		c.logf("Clamping server-requested retry wait of %s to %s", wait, c.maxRetryAfter)
+		return c.maxRetryAfter
+	}
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date. It returns 0 if the header is empty, invalid or in the past.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(header); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,298 @@
+package screencraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	schedulesEndpoint      = "/schedules"
+	scheduleEndpoint       = "/schedules/%s"
+	pauseScheduleEndpoint  = "/schedules/%s/pause"
+	resumeScheduleEndpoint = "/schedules/%s/resume"
+)
+
+// ErrMissingCron is returned when a schedule has no cron expression.
+var ErrMissingCron = errors.New("screencraft: cron expression is required")
+
+// ScheduleStatus is the state of a schedule.
+type ScheduleStatus string
+
+const (
+	// ScheduleActive is a schedule that captures on every run.
+	ScheduleActive ScheduleStatus = "active"
+	// SchedulePaused is a schedule whose runs are skipped until it is resumed.
+	SchedulePaused ScheduleStatus = "paused"
+)
+
+// Schedule is a recurring screenshot capture run by the API.
+type Schedule struct {
+	// ID identifies the schedule. It is set by the API.
+	ID string `json:"id,omitempty"`
+	// Name is an optional label for the schedule.
+	Name string `json:"name,omitempty"`
+	// Cron is a standard five-field cron expression (minute, hour, day of
+	// month, month, day of week), or a descriptor such as "@hourly".
+	Cron string `json:"cron"`
+	// Timezone is the IANA time zone Cron is evaluated in (default: UTC).
+	Timezone string `json:"timezone,omitempty"`
+	// ScreenshotOptions are the options of every capture.
+	ScreenshotOptions *ScreenshotOptions `json:"screenshot"`
+	// Webhook receives the result of every capture.
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	// Status is the state of the schedule. It is set by the API.
+	Status ScheduleStatus `json:"status,omitempty"`
+	// NextRunAt is when the schedule runs next. It is set by the API.
+	NextRunAt time.Time `json:"nextRunAt,omitempty"`
+	// LastRunAt is when the schedule last ran, if it has. It is set by the API.
+	LastRunAt time.Time `json:"lastRunAt,omitempty"`
+	// CreatedAt is when the schedule was created. It is set by the API.
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+// scheduleResponse is the API response for a single schedule.
+type scheduleResponse struct {
+	APIResponse
+	Data *Schedule `json:"data,omitempty"`
+}
+
+// schedulesResponse is the API response for a schedule listing.
+type schedulesResponse struct {
+	APIResponse
+	Data []*Schedule `json:"data,omitempty"`
+}
+
+// CreateSchedule creates a recurring capture and returns it as stored by the
+// API, with its ID and next run time set.
+//
+// Example:
+//
+//	schedule, err := client.CreateSchedule(ctx, &screencraft.Schedule{
+//	    Cron:              "0 * * * *",
+//	    ScreenshotOptions: &screencraft.ScreenshotOptions{URL: "https://example.com"},
+//	    Webhook:           &screencraft.WebhookConfig{URL: "https://myapp.com/webhook"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Schedule %s runs next at %s\n", schedule.ID, schedule.NextRunAt)
+func (c *Client) CreateSchedule(ctx context.Context, schedule *Schedule) (*Schedule, error) {
+	if err := ValidateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	req := map[string]interface{}{
+		"cron":       schedule.Cron,
+		"screenshot": c.buildScreenshotRequest(schedule.ScreenshotOptions),
+	}
+	if schedule.Name != "" {
+		req["name"] = schedule.Name
+	}
+	if schedule.Timezone != "" {
+		req["timezone"] = schedule.Timezone
+	}
+	if schedule.Webhook != nil {
+		req["webhook"] = schedule.Webhook
+	}
+
+	// Retries must not create the schedule twice
+	ctx = withCallOptions(ctx, []CallOption{withIdempotencyKey(newIdempotencyKey())})
+	return c.scheduleRequest(ctx, http.MethodPost, schedulesEndpoint, req)
+}
+
+// GetSchedule returns the schedule with the given ID.
+func (c *Client) GetSchedule(ctx context.Context, id string) (*Schedule, error) {
+	if id == "" {
+		return nil, NewValidationError("id", "schedule ID is required", "required").Error
+	}
+	return c.scheduleRequest(ctx, http.MethodGet, fmt.Sprintf(scheduleEndpoint, url.PathEscape(id)), nil)
+}
+
+// ListSchedules returns the account's schedules.
+//
+// Example:
+//
+//	schedules, err := client.ListSchedules(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range schedules {
+//	    fmt.Printf("%s %s %s\n", s.ID, s.Cron, s.Status)
+//	}
+func (c *Client) ListSchedules(ctx context.Context) ([]*Schedule, error) {
+	resp, _, err := c.doRequest(ctx, http.MethodGet, schedulesEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var schedulesResp schedulesResponse
+	if err := c.decodeJSON(body, &schedulesResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !schedulesResp.Success {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    schedulesResp.Message,
+		}
+	}
+
+	return schedulesResp.Data, nil
+}
+
+// PauseSchedule stops a schedule from running until ResumeSchedule is
+// called, and returns the updated schedule.
+func (c *Client) PauseSchedule(ctx context.Context, id string) (*Schedule, error) {
+	if id == "" {
+		return nil, NewValidationError("id", "schedule ID is required", "required").Error
+	}
+	return c.scheduleRequest(ctx, http.MethodPost, fmt.Sprintf(pauseScheduleEndpoint, url.PathEscape(id)), nil)
+}
+
+// ResumeSchedule resumes a paused schedule and returns the updated schedule.
+func (c *Client) ResumeSchedule(ctx context.Context, id string) (*Schedule, error) {
+	if id == "" {
+		return nil, NewValidationError("id", "schedule ID is required", "required").Error
+	}
+	return c.scheduleRequest(ctx, http.MethodPost, fmt.Sprintf(resumeScheduleEndpoint, url.PathEscape(id)), nil)
+}
+
+// DeleteSchedule deletes a schedule. Captures already running are not
+// canceled.
+func (c *Client) DeleteSchedule(ctx context.Context, id string) error {
+	if id == "" {
+		return NewValidationError("id", "schedule ID is required", "required").Error
+	}
+
+	resp, _, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf(scheduleEndpoint, url.PathEscape(id)), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	var apiResp APIResponse
+	if err := c.decodeJSON(body, &apiResp); err != nil {
+		return fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !apiResp.Success {
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Message:    apiResp.Message,
+		}
+	}
+	return nil
+}
+
+// scheduleRequest sends a request whose response is a single schedule.
+func (c *Client) scheduleRequest(ctx context.Context, method, endpoint string, body interface{}) (*Schedule, error) {
+	resp, _, err := c.doRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var scheduleResp scheduleResponse
+	if err := c.decodeJSON(respBody, &scheduleResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !scheduleResp.Success || scheduleResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    scheduleResp.Message,
+		}
+	}
+
+	return scheduleResp.Data, nil
+}
+
+// ValidateSchedule validates a schedule before it is created.
+func ValidateSchedule(schedule *Schedule) error {
+	if schedule == nil || schedule.Cron == "" {
+		return ErrMissingCron
+	}
+
+	if err := validateCron(schedule.Cron); err != nil {
+		return err
+	}
+
+	if schedule.Timezone != "" {
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			return NewValidationError("timezone", fmt.Sprintf("unknown time zone %q", schedule.Timezone), "enum").Error
+		}
+	}
+
+	if schedule.ScreenshotOptions == nil {
+		return NewValidationError("screenshot", "screenshot options are required", "required").Error
+	}
+	if err := ValidateScreenshotOptions(schedule.ScreenshotOptions); err != nil {
+		return err
+	}
+
+	if schedule.Webhook != nil {
+		if _, err := url.ParseRequestURI(schedule.Webhook.URL); err != nil {
+			return NewValidationError("webhook.url", "must be a valid URL", "url").Error
+		}
+	}
+	return nil
+}
+
+// cronDescriptors are the predefined schedules accepted instead of fields.
+var cronDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// validateCron checks the shape of a cron expression. The API validates the
+// field values.
+func validateCron(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		if !cronDescriptors[expr] {
+			return NewValidationError("cron", fmt.Sprintf("unknown descriptor %q", expr), "format").Error
+		}
+		return nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return NewValidationError("cron", fmt.Sprintf("expected 5 fields, got %d", len(fields)), "format").Error
+	}
+	for _, field := range fields {
+		if strings.Trim(field, "0123456789*,-/?ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz") != "" {
+			return NewValidationError("cron", fmt.Sprintf("invalid field %q", field), "format").Error
+		}
+	}
+	return nil
+}
@@ -24,12 +24,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math"
-	"math/rand"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -50,6 +53,9 @@ const (
 	// DefaultRetryWaitMax is the default maximum retry wait time.
 	DefaultRetryWaitMax = 30 * time.Second
 
+	// DefaultMaxRetryAfter is the default cap on server-requested retry waits.
+	DefaultMaxRetryAfter = 60 * time.Second
+
 	// Version is the SDK version.
 	Version = "1.0.0"
 )
@@ -74,6 +80,12 @@ type Client struct {
 	// retryWaitMax is the maximum time to wait between retries.
 	retryWaitMax time.Duration
 
+	// retryPolicy replaces the default exponential backoff, if set.
+	retryPolicy RetryPolicy
+
+	// maxRetryAfter caps server-requested retry waits, if positive.
+	maxRetryAfter time.Duration
+
 	// userAgent is the User-Agent header value.
 	userAgent string
 
@@ -88,6 +100,116 @@ type Client struct {
 
 	// lastRateLimit stores the last rate limit info received.
 	lastRateLimit *RateLimitInfo
+
+	// previousAPIKey is the key replaced by the last rotation.
+	previousAPIKey string
+
+	// previousKeyExpiry is when previousAPIKey stops being used as a fallback.
+	previousKeyExpiry time.Time
+
+	// credentialsKey is the last key returned by credentials, so it is
+	// redacted like apiKey.
+	credentialsKey string
+
+	// strictDecoding rejects unknown fields in API responses.
+	strictDecoding bool
+
+	// batchScheduler limits and prioritizes batch captures.
+	batchScheduler *scheduler
+
+	// compatibilityMode selects the API dialect.
+	compatibilityMode CompatibilityMode
+
+	// cache stores synchronous capture results, if enabled.
+	cache Cache
+
+	// cacheTTL is the default time to live of cached results.
+	cacheTTL time.Duration
+
+	// respectCacheHeaders lets Cache-Control headers decide cache TTLs.
+	respectCacheHeaders bool
+
+	// politeness paces batch captures per target host, if set.
+	politeness Politeness
+
+	// signingKeyID identifies the key used to sign capture URLs.
+	signingKeyID string
+
+	// signingSecret is the secret used to sign capture URLs.
+	signingSecret string
+
+	// apiVersion is the API version the client is pinned to, if any.
+	apiVersion string
+
+	// serverAPIVersion is the API version reported in the last response.
+	serverAPIVersion string
+
+	// requestMiddleware runs before each request attempt.
+	requestMiddleware []RequestMiddleware
+
+	// responseHooks run after each request attempt.
+	responseHooks []ResponseHook
+
+	// onRetry runs whenever a request is about to be retried.
+	onRetry []func(attempt int, err error, wait time.Duration)
+
+	// rateLimiter paces outgoing requests, if set.
+	rateLimiter *tokenBucket
+
+	// adaptiveThrottling slows requests down as the API rate limit runs out.
+	adaptiveThrottling bool
+
+	// quota paces requests as the rate limit or credits run low, if set.
+	quota *quotaPacer
+
+	// metrics receives measurements of HTTP traffic, if set.
+	metrics MetricsCollector
+
+	// stats accumulates the counters behind Stats.
+	stats *clientStats
+
+	// slog receives structured logs, if set.
+	slog *slog.Logger
+
+	// transcript records every attempt, if set.
+	transcript *transcript
+
+	// clock is the source of time for retry and rate limit waits.
+	clock Clock
+
+	// rand is the source of retry jitter, if set.
+	rand *lockedRand
+
+	// noRedaction disables redaction of secrets in logs, transcripts and
+	// errors.
+	noRedaction bool
+
+	// flights coalesces identical in-flight captures, if set.
+	flights *flightGroup
+
+	// jobs records async jobs, if set.
+	jobs JobStore
+
+	// requestScheduler limits and prioritizes in-flight requests.
+	requestScheduler *scheduler
+
+	// hedgeDelay is how long an attempt waits before it is hedged, if positive.
+	hedgeDelay time.Duration
+
+	// baseURLs tracks the health of failover base URLs, if configured.
+	baseURLs *baseURLPool
+
+	// apiKeys spreads requests over several API keys, if configured.
+	apiKeys *apiKeyPool
+
+	// keySelection is the strategy for choosing among apiKeys.
+	keySelection KeySelection
+
+	// credentials supplies API keys in place of apiKey, if set.
+	credentials CredentialsProvider
+
+	// authScheme overrides how the API key is sent, if set.
+	authScheme AuthScheme
 }
 
 // Logger is the interface for logging.
@@ -101,15 +223,20 @@ type Option func(*Client)
 // New creates a new ScreenCraft client with the given API key.
 func New(apiKey string, opts ...Option) *Client {
 	c := &Client{
-		apiKey:       apiKey,
-		baseURL:      DefaultBaseURL,
-		maxRetries:   DefaultMaxRetries,
-		retryWaitMin: DefaultRetryWaitMin,
-		retryWaitMax: DefaultRetryWaitMax,
-		userAgent:    fmt.Sprintf("screencraft-go/%s", Version),
+		apiKey:        apiKey,
+		baseURL:       DefaultBaseURL,
+		maxRetries:    DefaultMaxRetries,
+		retryWaitMin:  DefaultRetryWaitMin,
+		retryWaitMax:  DefaultRetryWaitMax,
+		maxRetryAfter: DefaultMaxRetryAfter,
+		userAgent:     fmt.Sprintf("screencraft-go/%s", Version),
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
+		batchScheduler:   newScheduler(DefaultBatchConcurrency),
+		requestScheduler: newScheduler(0),
+		stats:            newClientStats(),
+		clock:            systemClock{},
 	}
 
 	for _, opt := range opts {
@@ -123,6 +250,7 @@ func New(apiKey string, opts ...Option) *Client {
 func WithBaseURL(url string) Option {
 	return func(c *Client) {
 		c.baseURL = url
+		c.baseURLs = nil
 	}
 }
 
@@ -176,6 +304,14 @@ func WithLogger(logger Logger) Option {
 	}
 }
 
+// WithStrictDecoding makes response decoding fail on fields the SDK does not
+// know about. This is useful in staging to surface API contract drift early.
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Client) {
+		c.strictDecoding = strict
+	}
+}
+
 // SetAPIKey updates the API key.
 func (c *Client) SetAPIKey(apiKey string) {
 	c.mu.Lock()
@@ -183,6 +319,70 @@ func (c *Client) SetAPIKey(apiKey string) {
 	c.apiKey = apiKey
 }
 
+// RotateAPIKey switches the client to newKey while keeping the current key as
+// a fallback for the given overlap period.
+//
+// Requests are sent with the new key immediately. If the API rejects a request
+// with 401 during the overlap, it is retried once with the previous key, so
+// in-flight rotations on the API side do not cause failures. The overlap ends
+// early if ctx is canceled.
+//
+// Example:
+//
+//	err := client.RotateAPIKey(ctx, newKey, 10*time.Minute)
+func (c *Client) RotateAPIKey(ctx context.Context, newKey string, overlap time.Duration) error {
+	if newKey == "" {
+		return ErrMissingAPIKey
+	}
+
+	c.mu.Lock()
+	previousKey := c.apiKey
+	c.apiKey = newKey
+	if previousKey != "" && previousKey != newKey && overlap > 0 {
+		c.previousAPIKey = previousKey
+		c.previousKeyExpiry = time.Now().Add(overlap)
+	} else {
+		c.previousAPIKey = ""
+		c.previousKeyExpiry = time.Time{}
+	}
+	c.mu.Unlock()
+
+	if overlap <= 0 || ctx.Done() == nil {
+		return nil
+	}
+
+	go func() {
+		timer := time.NewTimer(overlap)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.previousAPIKey == previousKey {
+			c.previousAPIKey = ""
+			c.previousKeyExpiry = time.Time{}
+		}
+	}()
+
+	return nil
+}
+
+// previousKeyFor returns the previous API key if a rotation overlap is active
+// and apiKey is the current key, or an empty string otherwise.
+func (c *Client) previousKeyFor(apiKey string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.previousAPIKey == "" || apiKey != c.apiKey || time.Now().After(c.previousKeyExpiry) {
+		return ""
+	}
+	return c.previousAPIKey
+}
+
 // GetRateLimitInfo returns the last rate limit information received.
 func (c *Client) GetRateLimitInfo() *RateLimitInfo {
 	c.mu.RLock()
@@ -190,102 +390,313 @@ func (c *Client) GetRateLimitInfo() *RateLimitInfo {
 	return c.lastRateLimit
 }
 
+// Do performs a raw API request against the given path, relative to the base
+// URL, with the client's authentication, retry and rate limit handling.
+//
+// It is an escape hatch for endpoints the SDK has no typed wrapper for yet.
+// body, if non-nil, is sent as JSON. The response is decoded as JSON into
+// into, unless into is a *[]byte, in which case the raw body is stored. into
+// may be nil to discard the response.
+//
+// Example:
+//
+//	var usage map[string]interface{}
+//	err := client.Do(ctx, http.MethodGet, "/usage", nil, &usage)
+func (c *Client) Do(ctx context.Context, method, path string, body, into interface{}) error {
+	if path != "" && path[0] != '/' {
+		path = "/" + path
+	}
+
+	resp, _, err := c.doRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	switch v := into.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*v = data
+		return nil
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := c.decodeJSON(data, into); err != nil {
+		return fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+	return nil
+}
+
 // doRequest performs an HTTP request with retries.
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
-	if c.apiKey == "" {
-		return nil, ErrMissingAPIKey
+//
+// The returned RetryInfo describes the attempts made. It is also attached to
+// returned API errors.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, RetryInfo, error) {
+	var info RetryInfo
+
+	c.mu.RLock()
+	apiKey := c.apiKey
+	c.mu.RUnlock()
+
+	if apiKey == "" && c.credentials == nil {
+		return nil, info, ErrMissingAPIKey
 	}
 
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("screencraft: failed to marshal request body: %w", err)
+			return nil, info, fmt.Errorf("screencraft: failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	url := c.baseURL + endpoint
+	maxRetries, httpClient := c.callSettings(ctx)
+	policy := c.activeRetryPolicy()
+
+	// Retries and hedges of a call share one key, so the API processes the
+	// call once even if an earlier attempt reached it
+	idempotencyKey := callOptionsFrom(ctx).idempotencyKey
+	if idempotencyKey == "" && c.hedgeDelay > 0 {
+		idempotencyKey = newIdempotencyKey()
+	}
 
 	var lastErr error
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			waitTime := c.calculateBackoff(attempt, lastErr)
-			c.logf("Retrying request (attempt %d/%d) after %s", attempt+1, c.maxRetries+1, waitTime)
-
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(waitTime):
+	usedPreviousKey := false
+	switchedKey := false
+	keySwitches := 0
+	refreshedKey := false
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 && !usedPreviousKey && !switchedKey {
+			waitTime := c.clampRetryAfter(policy.Backoff(attempt, lastErr), lastErr)
+			c.logf("Retrying request (attempt %d/%d) after %s", attempt+1, maxRetries+1, waitTime)
+			c.runOnRetry(attempt+1, lastErr, waitTime)
+			c.observeRetry(endpoint)
+			c.logRetry(ctx, method, endpoint, attempt+1, waitTime, lastErr)
+
+			if err := c.sleep(ctx, waitTime); err != nil {
+				return nil, info, err
 			}
-
-			// Reset body reader for retry
-			if body != nil {
-				jsonBody, _ := json.Marshal(body)
-				bodyReader = bytes.NewReader(jsonBody)
+			info.RetryWaits = append(info.RetryWaits, waitTime)
+		}
+		switchedKey = false
+
+		keyIndex := -1
+		switch {
+		case usedPreviousKey:
+		case c.credentials != nil:
+			key, err := c.currentAPIKey(ctx)
+			if err != nil {
+				return nil, info, err
 			}
+			if key == "" {
+				return nil, info, ErrMissingAPIKey
+			}
+			apiKey = key
+		case c.apiKeys != nil:
+			apiKey, keyIndex = c.apiKeys.pick(c.keySelection, c.now())
+		}
+
+		var bodyReader io.Reader
+		if jsonBody != nil {
+			bodyReader = bytes.NewReader(jsonBody)
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		base, region := c.selectBaseURL()
+		url := c.versionedBaseURL(base) + c.endpointPath(endpoint)
+
+		trace := newRequestTrace()
+		req, err := http.NewRequestWithContext(trace.withTrace(ctx), method, url, bodyReader)
 		if err != nil {
-			return nil, fmt.Errorf("screencraft: failed to create request: %w", err)
+			return nil, info, fmt.Errorf("screencraft: failed to create request: %w", err)
+		}
+
+		c.setRequestHeaders(req, apiKey)
+		if etag := callOptionsFrom(ctx).ifNoneMatch; etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		for name, value := range callOptionsFrom(ctx).headers {
+			req.Header.Set(name, value)
+		}
+		if idempotencyKey != "" {
+			req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+		}
+
+		if err := c.runRequestMiddleware(req); err != nil {
+			return nil, info, err
 		}
 
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept", "application/json, image/*, application/pdf")
-		req.Header.Set("User-Agent", c.userAgent)
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(ctx, c); err != nil {
+				return nil, info, err
+			}
+		}
+		if err := c.throttle(ctx); err != nil {
+			return nil, info, err
+		}
+		if err := c.paceQuota(ctx); err != nil {
+			return nil, info, err
+		}
 
 		c.logf("Making %s request to %s", method, url)
 
-		resp, err := c.httpClient.Do(req)
+		release, err := c.requestScheduler.acquire(ctx, callOptionsFrom(ctx).priority)
+		if err != nil {
+			return nil, info, err
+		}
+
+		info.Attempts++
+		sent := time.Now()
+		resp, err := c.send(httpClient, req)
+		latency := time.Since(sent)
 		if err != nil {
-			lastErr = NewNetworkError(err)
-			if !IsRetryable(lastErr) || attempt == c.maxRetries {
-				return nil, lastErr
+			release()
+			if ctx.Err() == nil {
+				c.reportBaseURL(region, true, 0)
+			}
+			if timeoutErr := trace.timeoutError(err); timeoutErr != nil {
+				lastErr = timeoutErr
+			} else {
+				lastErr = NewNetworkError(err)
+			}
+			c.observeAttempt(endpoint, 0, latency, lastErr)
+			c.logAttempt(ctx, method, endpoint, info.Attempts, 0, "", latency, lastErr)
+			c.recordTranscript(req, jsonBody, info.Attempts, nil, latency, lastErr)
+			c.runResponseHooks(nil, lastErr, info.Attempts)
+			if attempt == maxRetries || !policy.ShouldRetry(lastErr, attempt+1) {
+				return nil, info, withRetryInfo(lastErr, info)
 			}
 			continue
 		}
 
-		// Parse rate limit headers
-		c.parseRateLimitHeaders(resp)
+		c.reportBaseURL(region, false, resp.StatusCode)
+
+		// Parse rate limit and version headers
+		rateLimit := c.parseRateLimitHeaders(resp)
+		c.recordServerVersion(resp)
 
 		// Check for errors
 		if resp.StatusCode >= 400 {
 			lastErr = c.parseErrorResponse(resp)
-			if !IsRetryable(lastErr) || attempt == c.maxRetries {
-				return nil, lastErr
+			release()
+			c.observeAttempt(endpoint, resp.StatusCode, latency, lastErr)
+			c.logAttempt(ctx, method, endpoint, info.Attempts, resp.StatusCode, resp.Header.Get("X-Request-ID"), latency, lastErr)
+			c.recordTranscript(req, jsonBody, info.Attempts, resp, latency, lastErr)
+			c.runResponseHooks(resp, lastErr, info.Attempts)
+
+			// With several keys, retry at once with another key
+			if c.reportAPIKey(keyIndex, resp.StatusCode, rateLimit, lastErr) && keySwitches < len(c.apiKeys.keys)-1 {
+				c.logf("Request rejected with API key %d, retrying with another key", keyIndex+1)
+				keySwitches++
+				switchedKey = true
+				attempt--
+				continue
+			}
+
+			// Provided keys may be revoked before they expire; retry once
+			// with a new one
+			if resp.StatusCode == http.StatusUnauthorized && c.credentials != nil && !refreshedKey && c.invalidateAPIKey(apiKey) {
+				c.logf("Request rejected with provided API key, retrying with a new key")
+				refreshedKey = true
+				switchedKey = true
+				attempt--
+				continue
+			}
+
+			// During a key rotation, retry once with the previous key
+			if resp.StatusCode == http.StatusUnauthorized && !usedPreviousKey {
+				if previousKey := c.previousKeyFor(apiKey); previousKey != "" {
+					c.logf("Request rejected with new API key, retrying with previous key")
+					apiKey = previousKey
+					usedPreviousKey = true
+					attempt--
+					continue
+				}
+			}
+			usedPreviousKey = false
+
+			if attempt == maxRetries || !policy.ShouldRetry(lastErr, attempt+1) {
+				return nil, info, withRetryInfo(lastErr, info)
 			}
-			resp.Body.Close()
 			continue
 		}
 
-		return resp, nil
+		c.reportAPIKey(keyIndex, resp.StatusCode, rateLimit, nil)
+		c.observeAttempt(endpoint, resp.StatusCode, latency, nil)
+		c.logAttempt(ctx, method, endpoint, info.Attempts, resp.StatusCode, resp.Header.Get("X-Request-ID"), latency, nil)
+		c.recordTranscript(req, jsonBody, info.Attempts, resp, latency, nil)
+		c.runResponseHooks(resp, nil, info.Attempts)
+		resp.Body = &tracedBody{
+			ReadCloser: &releasingBody{
+				ReadCloser: &countingBody{ReadCloser: resp.Body, client: c, endpoint: endpoint},
+				release:    release,
+			},
+			trace: trace,
+		}
+		return resp, info, nil
 	}
 
-	return nil, lastErr
+	return nil, info, withRetryInfo(lastErr, info)
 }
 
-// calculateBackoff calculates the backoff duration for a retry.
-func (c *Client) calculateBackoff(attempt int, lastErr error) time.Duration {
-	// Check for Retry-After from rate limit errors
-	if retryAfter := GetRetryAfter(lastErr); retryAfter > 0 {
-		return retryAfter
+// callSettings returns the maximum number of retries and the HTTP client to
+// use for a call, applying per-call overrides carried by ctx.
+func (c *Client) callSettings(ctx context.Context) (int, *http.Client) {
+	o := callOptionsFrom(ctx)
+
+	maxRetries := c.maxRetries
+	if o.maxRetries != nil {
+		maxRetries = *o.maxRetries
+	}
+
+	httpClient := c.httpClient
+	if o.timeout > 0 && o.timeout != httpClient.Timeout {
+		clone := *httpClient
+		clone.Timeout = o.timeout
+		httpClient = &clone
 	}
 
-	// Exponential backoff with jitter
-	backoff := float64(c.retryWaitMin) * math.Pow(2, float64(attempt-1))
-	if backoff > float64(c.retryWaitMax) {
-		backoff = float64(c.retryWaitMax)
+	return maxRetries, httpClient
+}
+
+// setRequestHeaders sets the headers the SDK sends with every request.
+func (c *Client) setRequestHeaders(req *http.Request, apiKey string) {
+	c.setAuthHeader(req, apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, image/*, application/pdf")
+	req.Header.Set("User-Agent", c.userAgent)
+	c.setVersionHeader(req)
+}
+
+// withRetryInfo attaches retry information to an API error.
+func withRetryInfo(err error, info RetryInfo) error {
+	if scErr := asError(err); scErr != nil {
+		scErr.RetryInfo = info
 	}
+	return err
+}
 
-	// Add jitter (up to 25%)
-	jitter := backoff * 0.25 * rand.Float64()
-	return time.Duration(backoff + jitter)
+// newResponseInfo returns the ResponseInfo of resp for a call started at start.
+func newResponseInfo(resp *http.Response, start time.Time) ResponseInfo {
+	return ResponseInfo{
+		RequestID: resp.Header.Get("X-Request-ID"),
+		Duration:  time.Since(start),
+		Headers:   resp.Header,
+	}
 }
 
-// parseRateLimitHeaders parses rate limit information from response headers.
-func (c *Client) parseRateLimitHeaders(resp *http.Response) {
+// parseRateLimitHeaders parses rate limit information from response headers
+// and returns it, or nil if the response has none.
+func (c *Client) parseRateLimitHeaders(resp *http.Response) *RateLimitInfo {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -293,13 +704,15 @@ func (c *Client) parseRateLimitHeaders(resp *http.Response) {
 	remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
 	resetUnix, _ := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
 
-	if limit > 0 || remaining > 0 || resetUnix > 0 {
-		c.lastRateLimit = &RateLimitInfo{
-			Limit:     limit,
-			Remaining: remaining,
-			Reset:     time.Unix(resetUnix, 0),
-		}
+	if limit <= 0 && remaining <= 0 && resetUnix <= 0 {
+		return nil
+	}
+	c.lastRateLimit = &RateLimitInfo{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(resetUnix, 0),
 	}
+	return c.lastRateLimit
 }
 
 // parseErrorResponse parses an error response from the API.
@@ -316,10 +729,11 @@ func (c *Client) parseErrorResponse(resp *http.Response) error {
 	}
 
 	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := c.decodeErrorBody(body, &apiResp); err != nil {
+		// Proxies and gateways may echo the request, API key included
 		return &Error{
 			StatusCode: resp.StatusCode,
-			Message:    string(body),
+			Message:    c.redactString(string(body)),
 		}
 	}
 
@@ -330,10 +744,15 @@ func (c *Client) parseErrorResponse(resp *http.Response) error {
 
 	if apiResp.Error != nil {
 		baseErr.Code = apiResp.Error.Code
-		baseErr.Message = apiResp.Error.Message
-		baseErr.Details = apiResp.Error.Details
+		baseErr.Message = c.redactString(apiResp.Error.Message)
+		baseErr.Details = c.redactDetails(apiResp.Error.Details)
 	} else if apiResp.Message != "" {
-		baseErr.Message = apiResp.Message
+		baseErr.Message = c.redactString(apiResp.Message)
+	}
+
+	// Render-side timeouts (e.g. the target page failed to load in time)
+	if baseErr.Code == "TIMEOUT" || baseErr.Code == "NAVIGATION_TIMEOUT" {
+		return renderTimeoutError(baseErr)
 	}
 
 	// Handle specific error types
@@ -342,12 +761,7 @@ func (c *Client) parseErrorResponse(resp *http.Response) error {
 		return &AuthenticationError{Error: baseErr}
 
 	case http.StatusTooManyRequests:
-		retryAfter := time.Duration(0)
-		if ra := resp.Header.Get("Retry-After"); ra != "" {
-			if seconds, err := strconv.Atoi(ra); err == nil {
-				retryAfter = time.Duration(seconds) * time.Second
-			}
-		}
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), c.now())
 
 		limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
 		remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
@@ -382,16 +796,94 @@ func (c *Client) parseErrorResponse(resp *http.Response) error {
 		http.StatusBadGateway,
 		http.StatusServiceUnavailable,
 		http.StatusGatewayTimeout:
-		return &ServerError{Error: baseErr}
+		return &ServerError{
+			Error:      baseErr,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), c.now()),
+		}
 	}
 
 	return baseErr
 }
 
-// logf logs a message if debug mode is enabled.
+// renderTimeoutError converts a timeout reported by the API into a
+// TimeoutError. The phase and timeout (in milliseconds) are read from the
+// error details when present.
+func renderTimeoutError(baseErr *Error) *TimeoutError {
+	timeoutErr := &TimeoutError{
+		Error: baseErr,
+		Phase: PhaseNavigation,
+	}
+
+	if phase, ok := baseErr.Details["phase"].(string); ok && phase != "" {
+		timeoutErr.Phase = TimeoutPhase(phase)
+	}
+
+	if ms, ok := baseErr.Details["timeout"].(json.Number); ok {
+		if v, err := ms.Int64(); err == nil {
+			timeoutErr.Duration = time.Duration(v) * time.Millisecond
+		}
+	}
+
+	return timeoutErr
+}
+
+// decodeJSON decodes an API response body into v.
+//
+// Numbers in untyped values (such as error details) are decoded as json.Number
+// to avoid float64 precision loss. Unknown fields are rejected in strict mode.
+func (c *Client) decodeJSON(data []byte, v interface{}) error {
+	return decodeJSONValue(data, v, c.strictDecoding)
+}
+
+// decodeJSONValue decodes a single JSON value with numbers as json.Number,
+// rejecting unknown fields if strict is set.
+func decodeJSONValue(data []byte, v interface{}, strict bool) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if dec.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// parseAssertionsHeader parses text assertion results returned alongside a
+// binary artifact in the X-Assertions header.
+func (c *Client) parseAssertionsHeader(resp *http.Response) (Assertions, error) {
+	header := resp.Header.Get("X-Assertions")
+	if header == "" {
+		return nil, nil
+	}
+
+	var assertions Assertions
+	if err := c.decodeJSON([]byte(header), &assertions); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse assertion results: %w", err)
+	}
+	return assertions, nil
+}
+
+// logf logs a message if debug mode is enabled, or at Debug level to the
+// structured logger if one is set.
 func (c *Client) logf(format string, v ...interface{}) {
-	if c.debug && c.logger != nil {
-		c.logger.Printf(format, v...)
+	toSlog := c.slog != nil && c.slog.Enabled(context.Background(), slog.LevelDebug)
+	toLogger := c.debug && c.logger != nil
+	if !toSlog && !toLogger {
+		return
+	}
+
+	msg := c.redactString(fmt.Sprintf(format, v...))
+	if toSlog {
+		c.slog.Debug(msg)
+	}
+	if toLogger {
+		c.logger.Printf("%s", msg)
 	}
 }
 
@@ -405,16 +897,118 @@ func ValidateScreenshotOptions(opts *ScreenshotOptions) error {
 		return ErrMissingURL
 	}
 
+	if _, err := NormalizeTargetURL(opts.URL); err != nil {
+		return err
+	}
+
 	if opts.Quality < 0 || opts.Quality > 100 {
 		return ErrInvalidQuality
 	}
 
+	for _, f := range opts.Formats {
+		if !f.IsValid() {
+			return fmt.Errorf("%w: %q", ErrInvalidFormat, f)
+		}
+		if opts.OmitBackground && f == FormatJPEG {
+			return NewValidationError("omitBackground", "transparent background requires png or webp format", "format").Error
+		}
+	}
+
+	if opts.OmitBackground && opts.Format == FormatJPEG {
+		return NewValidationError("omitBackground", "transparent background requires png or webp format", "format").Error
+	}
+
 	if opts.Viewport != nil {
 		if opts.Viewport.Width < 0 || opts.Viewport.Height < 0 {
 			return ErrInvalidViewport
 		}
 	}
 
+	if opts.Resize != nil {
+		if err := validateDimensions("resize", opts.Resize.Width, opts.Resize.Height, opts.Resize.Fit); err != nil {
+			return err
+		}
+		if opts.Resize.Algorithm != "" && !opts.Resize.Algorithm.IsValid() {
+			return NewValidationError("resize.algorithm", fmt.Sprintf("unknown resize algorithm %q", opts.Resize.Algorithm), "enum").Error
+		}
+	}
+
+	if opts.Thumbnail != nil {
+		if err := validateDimensions("thumbnail", opts.Thumbnail.Width, opts.Thumbnail.Height, opts.Thumbnail.Fit); err != nil {
+			return err
+		}
+	}
+
+	if opts.InjectCSSURL != "" {
+		if _, err := NormalizeTargetURL(opts.InjectCSSURL); err != nil {
+			return NewValidationError("injectCSSURL", err.Error(), "url").Error
+		}
+	}
+
+	if opts.ScrollStep < 0 || opts.ScrollPause < 0 {
+		return NewValidationError("scrollThrough", "scroll step and pause must not be negative", "range").Error
+	}
+
+	if err := validateWaitSelectors(opts.WaitForSelectors, opts.WaitMode); err != nil {
+		return err
+	}
+
+	if opts.WaitForFunctionTimeout < 0 {
+		return NewValidationError("waitForFunctionTimeout", "waitForFunctionTimeout must not be negative", "range").Error
+	}
+
+	if opts.WaitForRequest != "" {
+		if err := validateURLPattern("waitForRequest", opts.WaitForRequest); err != nil {
+			return err
+		}
+	}
+
+	if opts.WaitForResponse != "" {
+		if err := validateURLPattern("waitForResponse", opts.WaitForResponse); err != nil {
+			return err
+		}
+	}
+
+	if err := validateActions(opts.Actions); err != nil {
+		return err
+	}
+
+	if err := validateURLPatterns(opts.BlockURLPatterns); err != nil {
+		return err
+	}
+
+	if err := validateLogin(opts.Login); err != nil {
+		return err
+	}
+
+	if err := validateBasicAuth(opts.BasicAuth); err != nil {
+		return err
+	}
+
+	if err := validateGeolocation(opts.Geolocation); err != nil {
+		return err
+	}
+
+	if err := validateProxy(opts.Proxy); err != nil {
+		return err
+	}
+
+	if err := validateWatermark(opts.Watermark); err != nil {
+		return err
+	}
+
+	if err := validateStorage(opts.Storage); err != nil {
+		return err
+	}
+
+	if !opts.Priority.IsValid() {
+		return NewValidationError("priority", fmt.Sprintf("unknown priority %d", int(opts.Priority)), "enum").Error
+	}
+
+	if err := validateEmulateMedia(opts.EmulateMedia); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -424,20 +1018,347 @@ func ValidatePDFOptions(opts *PDFOptions) error {
 		return ErrMissingURL
 	}
 
-	if opts.URL == "" {
-		return ErrMissingURL
+	if len(opts.URLs) > 0 {
+		if opts.URL != "" {
+			return NewValidationError("urls", "url and urls are mutually exclusive", "exclusive").Error
+		}
+		for _, u := range opts.URLs {
+			if _, err := NormalizeTargetURL(u); err != nil {
+				return err
+			}
+		}
+	} else {
+		if opts.URL == "" {
+			return ErrMissingURL
+		}
+
+		if _, err := NormalizeTargetURL(opts.URL); err != nil {
+			return err
+		}
 	}
 
 	if opts.Scale != 0 && (opts.Scale < 0.1 || opts.Scale > 2.0) {
 		return NewValidationError("scale", "scale must be between 0.1 and 2.0", "range").Error
 	}
 
+	if opts.TOCTemplate != "" && !opts.GenerateOutline {
+		return NewValidationError("tocTemplate", "tocTemplate requires generateOutline", "dependency").Error
+	}
+
 	if opts.Viewport != nil {
 		if opts.Viewport.Width < 0 || opts.Viewport.Height < 0 {
 			return ErrInvalidViewport
 		}
 	}
 
+	if opts.InjectCSSURL != "" {
+		if _, err := NormalizeTargetURL(opts.InjectCSSURL); err != nil {
+			return NewValidationError("injectCSSURL", err.Error(), "url").Error
+		}
+	}
+
+	if opts.ScrollStep < 0 || opts.ScrollPause < 0 {
+		return NewValidationError("scrollThrough", "scroll step and pause must not be negative", "range").Error
+	}
+
+	if err := validateWaitSelectors(opts.WaitForSelectors, opts.WaitMode); err != nil {
+		return err
+	}
+
+	if opts.WaitForFunctionTimeout < 0 {
+		return NewValidationError("waitForFunctionTimeout", "waitForFunctionTimeout must not be negative", "range").Error
+	}
+
+	if opts.WaitForRequest != "" {
+		if err := validateURLPattern("waitForRequest", opts.WaitForRequest); err != nil {
+			return err
+		}
+	}
+
+	if opts.WaitForResponse != "" {
+		if err := validateURLPattern("waitForResponse", opts.WaitForResponse); err != nil {
+			return err
+		}
+	}
+
+	if err := validateActions(opts.Actions); err != nil {
+		return err
+	}
+
+	if err := validateURLPatterns(opts.BlockURLPatterns); err != nil {
+		return err
+	}
+
+	if err := validateLogin(opts.Login); err != nil {
+		return err
+	}
+
+	if err := validateBasicAuth(opts.BasicAuth); err != nil {
+		return err
+	}
+
+	if err := validateGeolocation(opts.Geolocation); err != nil {
+		return err
+	}
+
+	if err := validateProxy(opts.Proxy); err != nil {
+		return err
+	}
+
+	if err := validateWatermark(opts.Watermark); err != nil {
+		return err
+	}
+
+	if err := validateStorage(opts.Storage); err != nil {
+		return err
+	}
+
+	if !opts.Priority.IsValid() {
+		return NewValidationError("priority", fmt.Sprintf("unknown priority %d", int(opts.Priority)), "enum").Error
+	}
+
+	if err := validateEmulateMedia(opts.EmulateMedia); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGeolocation validates geolocation coordinates.
+func validateGeolocation(geo *Geolocation) error {
+	if geo == nil {
+		return nil
+	}
+
+	if geo.Latitude < -90 || geo.Latitude > 90 {
+		return NewValidationError("geolocation.latitude", "latitude must be between -90 and 90", "range").Error
+	}
+
+	if geo.Longitude < -180 || geo.Longitude > 180 {
+		return NewValidationError("geolocation.longitude", "longitude must be between -180 and 180", "range").Error
+	}
+
+	if geo.Accuracy < 0 {
+		return NewValidationError("geolocation.accuracy", "accuracy must not be negative", "range").Error
+	}
+
+	return nil
+}
+
+// validateWatermark validates a watermark overlay.
+func validateWatermark(wm *WatermarkOptions) error {
+	if wm == nil {
+		return nil
+	}
+
+	if (wm.Text == "") == (wm.ImageURL == "") {
+		return NewValidationError("watermark", "exactly one of watermark.text and watermark.imageUrl is required", "required").Error
+	}
+
+	if wm.ImageURL != "" {
+		if _, err := NormalizeTargetURL(wm.ImageURL); err != nil {
+			return NewValidationError("watermark.imageUrl", err.Error(), "url").Error
+		}
+	}
+
+	if wm.Position != "" && !wm.Position.IsValid() {
+		return NewValidationError("watermark.position", fmt.Sprintf("unknown watermark position %q", wm.Position), "enum").Error
+	}
+
+	if wm.Opacity < 0 || wm.Opacity > 1 {
+		return NewValidationError("watermark.opacity", "opacity must be between 0 and 1", "range").Error
+	}
+
+	return nil
+}
+
+// validateStorage validates a storage destination.
+func validateStorage(storage *StorageConfig) error {
+	if storage == nil {
+		return nil
+	}
+
+	if storage.Provider == "" {
+		return NewValidationError("storage.provider", "storage provider is required", "required").Error
+	}
+
+	if !storage.Provider.IsValid() {
+		return NewValidationError("storage.provider", fmt.Sprintf("unknown storage provider %q", storage.Provider), "enum").Error
+	}
+
+	if storage.Bucket == "" {
+		return NewValidationError("storage.bucket", "storage bucket is required", "required").Error
+	}
+
+	return nil
+}
+
+// validateProxy validates a proxy configuration.
+func validateProxy(proxy *ProxyConfig) error {
+	if proxy == nil {
+		return nil
+	}
+
+	if proxy.URL == "" && proxy.Country == "" {
+		return NewValidationError("proxy", "proxy.url or proxy.country is required", "required").Error
+	}
+
+	if proxy.URL != "" {
+		u, err := url.Parse(proxy.URL)
+		if err != nil || u.Host == "" {
+			return NewValidationError("proxy.url", "proxy URL is invalid", "url").Error
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return NewValidationError("proxy.url", fmt.Sprintf("unsupported proxy scheme %q", u.Scheme), "enum").Error
+		}
+	}
+
+	if proxy.Country != "" && !isCountryCode(proxy.Country) {
+		return NewValidationError("proxy.country", "proxy.country must be an ISO 3166-1 alpha-2 code", "format").Error
+	}
+
+	return nil
+}
+
+// isCountryCode reports whether s looks like an ISO 3166-1 alpha-2 code.
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateDimensions validates target dimensions and fit of a resize.
+func validateDimensions(field string, width, height int, fit Fit) error {
+	if width < 0 || height < 0 {
+		return NewValidationError(field, field+" dimensions must not be negative", "range").Error
+	}
+
+	if width == 0 && height == 0 {
+		return NewValidationError(field, field+" width or height is required", "required").Error
+	}
+
+	if fit != "" && !fit.IsValid() {
+		return NewValidationError(field+".fit", fmt.Sprintf("unknown fit %q", fit), "enum").Error
+	}
+
+	return nil
+}
+
+// validateWaitSelectors validates multiple selector waits.
+func validateWaitSelectors(selectors []string, mode WaitMode) error {
+	for i, selector := range selectors {
+		if strings.TrimSpace(selector) == "" {
+			return NewValidationError(fmt.Sprintf("waitForSelectors[%d]", i), "selector must not be empty", "required").Error
+		}
+	}
+
+	if mode != "" && !mode.IsValid() {
+		return NewValidationError("waitMode", fmt.Sprintf("unknown wait mode %q", mode), "enum").Error
+	}
+
+	return nil
+}
+
+// validateURLPatterns validates request blocking patterns.
+func validateURLPatterns(patterns []string) error {
+	for i, pattern := range patterns {
+		if err := validateURLPattern(fmt.Sprintf("blockURLPatterns[%d]", i), pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateURLPattern validates a glob or /regex/ URL pattern.
+func validateURLPattern(field, pattern string) error {
+	if pattern == "" {
+		return NewValidationError(field, "pattern must not be empty", "required").Error
+	}
+
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		if _, err := regexp.Compile(pattern[1 : len(pattern)-1]); err != nil {
+			return NewValidationError(field, fmt.Sprintf("invalid regular expression: %v", err), "regex").Error
+		}
+	}
+	return nil
+}
+
+// validateLogin validates a scripted login configuration.
+func validateLogin(login *LoginConfig) error {
+	if login == nil {
+		return nil
+	}
+
+	if _, err := NormalizeTargetURL(login.URL); err != nil {
+		return NewValidationError("login.url", "login URL is invalid", "url").Error
+	}
+
+	required := []struct {
+		field, value string
+	}{
+		{"login.usernameSelector", login.UsernameSelector},
+		{"login.passwordSelector", login.PasswordSelector},
+		{"login.submitSelector", login.SubmitSelector},
+		{"login.credentials.username", login.Credentials.Username},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			return NewValidationError(r.field, r.field+" is required", "required").Error
+		}
+	}
+
+	return nil
+}
+
+// validateBasicAuth validates HTTP Basic Auth credentials.
+func validateBasicAuth(auth *BasicAuth) error {
+	if auth == nil {
+		return nil
+	}
+
+	if auth.Username == "" {
+		return NewValidationError("basicAuth.username", "basicAuth.username is required", "required").Error
+	}
+
+	if strings.Contains(auth.Username, ":") {
+		return NewValidationError("basicAuth.username", "basicAuth.username must not contain a colon", "format").Error
+	}
+
+	return nil
+}
+
+// validateEmulateMedia validates emulated media feature values.
+func validateEmulateMedia(media *EmulateMedia) error {
+	if media == nil {
+		return nil
+	}
+
+	switch media.ReducedMotion {
+	case "", ReducedMotionReduce, ReducedMotionNoPreference:
+	default:
+		return NewValidationError("emulateMedia.reducedMotion", fmt.Sprintf("unknown reduced motion value %q", media.ReducedMotion), "enum").Error
+	}
+
+	switch media.ForcedColors {
+	case "", ForcedColorsActive, ForcedColorsNone:
+	default:
+		return NewValidationError("emulateMedia.forcedColors", fmt.Sprintf("unknown forced colors value %q", media.ForcedColors), "enum").Error
+	}
+
+	switch media.Contrast {
+	case "", ContrastMore, ContrastLess, ContrastCustom, ContrastNoPreference:
+	default:
+		return NewValidationError("emulateMedia.contrast", fmt.Sprintf("unknown contrast value %q", media.Contrast), "enum").Error
+	}
+
 	return nil
 }
 
@@ -0,0 +1,374 @@
+package screencrafttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNoInteraction is returned by a replaying Recorder when the cassette has
+// no unused interaction matching a request.
+var ErrNoInteraction = errors.New("screencrafttest: no recorded interaction matches request")
+
+// scrubbed replaces secrets in cassettes.
+const scrubbed = "[SCRUBBED]"
+
+// scrubbedHeaders are headers whose values are never written to cassettes.
+var scrubbedHeaders = []string{"Authorization", "Proxy-Authorization", "X-API-Key", "Cookie", "Set-Cookie"}
+
+// urlHeaders are headers carrying URLs, whose secret query parameters are
+// scrubbed.
+var urlHeaders = []string{"Location", "Content-Location", "Referer"}
+
+// scrubbedKeys are JSON keys and query parameters whose values are never
+// written to cassettes.
+var scrubbedKeys = map[string]bool{
+	"password":    true,
+	"secret":      true,
+	"token":       true,
+	"apikey":      true,
+	"api_key":     true,
+	"cookies":     true,
+	"credentials": true,
+}
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never calls the API.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and records them, replacing the
+	// cassette on Save.
+	ModeRecord
+	// ModeAuto replays if the cassette exists and records otherwise.
+	ModeAuto
+)
+
+// Cassette is a recorded sequence of API interactions.
+type Cassette struct {
+	// Interactions are the recorded request/response pairs, in order.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as stored in a cassette.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as stored in a cassette. Bodies are stored
+// base64-encoded, so binary captures survive the round trip.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records real API interactions to a
+// cassette file and replays them in CI. API keys (in headers or the query
+// string), cookies, passwords and other secrets are scrubbed before anything
+// is written.
+//
+// Requests match recorded interactions by method, path, query and JSON body,
+// ignoring the host, and each interaction is replayed once, in order.
+//
+// Example:
+//
+//	rec, err := screencrafttest.NewRecorder("testdata/home.json", screencrafttest.ModeAuto, nil)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer rec.Save()
+//
+//	client := screencraft.New(os.Getenv("SCREENCRAFT_API_KEY"),
+//	    screencraft.WithHTTPClient(&http.Client{Transport: rec}),
+//	)
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder for the cassette at path. transport sends
+// requests while recording; nil means http.DefaultTransport. In ModeReplay,
+// the cassette must exist.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, transport: transport}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil && mode != ModeRecord:
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("screencrafttest: failed to parse cassette %s: %w", path, err)
+		}
+		r.mode = ModeReplay
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case err == nil || errors.Is(err, os.ErrNotExist) && mode != ModeReplay:
+		r.mode = ModeRecord
+	default:
+		return nil, fmt.Errorf("screencrafttest: failed to read cassette: %w", err)
+	}
+	return r, nil
+}
+
+// Recording reports whether the recorder is sending requests to the API.
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    scrubURL(req.URL.RequestURI()),
+		Header: scrubHeader(req.Header),
+		Body:   scrubJSON(body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       respBody,
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching recorded.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !matches(in.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("screencrafttest: failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("screencrafttest: failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("screencrafttest: failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// matches reports whether a recorded request matches an incoming one.
+func matches(recorded, req RecordedRequest) bool {
+	if recorded.Method != req.Method || recorded.URL != req.URL {
+		return false
+	}
+	return bytes.Equal(canonicalJSON(recorded.Body), canonicalJSON(req.Body))
+}
+
+// canonicalJSON re-encodes a JSON value so that formatting differences do
+// not affect matching.
+func canonicalJSON(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+	out, _ := json.Marshal(v)
+	return out
+}
+
+// scrubURL returns a URL or request URI with secret query parameters, such
+// as the api_key parameter of screencraft.AuthQueryParam, replaced.
+func scrubURL(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.RawQuery == "" {
+		return uri
+	}
+
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if scrubbedKeys[strings.ToLower(key)] {
+			query.Set(key, scrubbed)
+			changed = true
+		}
+	}
+	if !changed {
+		return uri
+	}
+	u.RawQuery = query.Encode()
+	if u.IsAbs() {
+		return u.String()
+	}
+	return u.RequestURI()
+}
+
+// scrubHeader returns a copy of h with secrets replaced.
+func scrubHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range scrubbedHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, scrubbed)
+		}
+	}
+	for _, name := range urlHeaders {
+		if v := out.Get(name); v != "" {
+			out.Set(name, scrubURL(v))
+		}
+	}
+	return out
+}
+
+// scrubJSON returns a JSON body with secrets replaced. Bodies that are not
+// JSON are dropped, since they cannot be inspected.
+func scrubJSON(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	out, err := json.Marshal(scrubValue(v))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// scrubValue replaces secrets in a decoded JSON value.
+func scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if scrubbedKeys[strings.ToLower(k)] {
+				v[k] = scrubbed
+				continue
+			}
+			if strings.EqualFold(k, "headers") {
+				v[k] = scrubHeaderValues(e)
+				continue
+			}
+			v[k] = scrubValue(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = scrubValue(e)
+		}
+	}
+	return v
+}
+
+// scrubHeaderValues scrubs sensitive entries of a headers object, given as a
+// map or as a list of {name, value} objects.
+func scrubHeaderValues(v interface{}) interface{} {
+	sensitive := func(name string) bool {
+		for _, h := range scrubbedHeaders {
+			if strings.EqualFold(h, name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k := range v {
+			if sensitive(k) {
+				v[k] = scrubbed
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if m, ok := e.(map[string]interface{}); ok {
+				if name, _ := m["name"].(string); sensitive(name) {
+					m["value"] = scrubbed
+				}
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package screencrafttest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/screencrafttest"
+)
+
+func TestRecorderScrubsQueryAPIKey(t *testing.T) {
+	const apiKey = "sk_live_query_secret"
+
+	srv := screencrafttest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := screencrafttest.NewRecorder(path, screencrafttest.ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := screencraft.New(apiKey,
+		screencraft.WithBaseURL(srv.URL),
+		screencraft.WithAuthScheme(screencraft.AuthQueryParam),
+		screencraft.WithHTTPClient(&http.Client{Transport: rec}),
+	)
+	opts := &screencraft.ScreenshotOptions{URL: "https://example.com"}
+	if _, err := client.Screenshot(context.Background(), opts); err != nil {
+		t.Fatalf("Screenshot while recording: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), apiKey) {
+		t.Fatalf("cassette contains the API key:\n%s", data)
+	}
+	if !strings.Contains(string(data), "api_key=%5BSCRUBBED%5D") {
+		t.Errorf("cassette does not contain the scrubbed api_key parameter:\n%s", data)
+	}
+
+	// Replays match whatever key the client uses
+	rec, err = screencrafttest.NewRecorder(path, screencrafttest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = screencraft.New("sk_other_key",
+		screencraft.WithBaseURL("https://api.invalid"),
+		screencraft.WithAuthScheme(screencraft.AuthQueryParam),
+		screencraft.WithHTTPClient(&http.Client{Transport: rec}),
+	)
+	if _, err := client.Screenshot(context.Background(), opts); err != nil {
+		t.Fatalf("Screenshot while replaying: %v", err)
+	}
+}
+
+func TestRecorderScrubsURLsInHeaders(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://cdn.example.com/result.png?token=cdn_secret&size=large")
+		w.WriteHeader(http.StatusSeeOther)
+	}))
+	defer api.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := screencrafttest.NewRecorder(path, screencrafttest.ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, api.URL+"/jobs/job_1/result?api_key=sk_live_secret", nil)
+	req.Header.Set("Referer", "https://app.example.com/?secret=referer_secret")
+	resp, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"cdn_secret", "sk_live_secret", "referer_secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "size=large") {
+		t.Errorf("cassette lost non-secret query parameters:\n%s", data)
+	}
+}
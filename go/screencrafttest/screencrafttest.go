@@ -0,0 +1,402 @@
+// Package screencrafttest provides a fake ScreenCraft API for tests.
+//
+// The fake server implements the screenshot, PDF and health endpoints over
+// httptest, returns canned PNG, JPEG and PDF fixtures, and can simulate
+// latency, random failures and rate limiting, so integration tests run
+// without network access and without spending credits. Captures carry an
+// ETag, and conditional requests for an unchanged fixture get 304 Not
+// Modified.
+//
+// Example:
+//
+//	func TestArchive(t *testing.T) {
+//	    srv := screencrafttest.NewServer(
+//	        screencrafttest.WithLatency(50*time.Millisecond),
+//	        screencrafttest.WithErrorRate(0.1, http.StatusServiceUnavailable),
+//	    )
+//	    defer srv.Close()
+//
+//	    client := screencraft.New("test-key", screencraft.WithBaseURL(srv.URL))
+//	    result, err := client.Screenshot(ctx, &screencraft.ScreenshotOptions{URL: "https://example.com"})
+//	    ...
+//	}
+package screencrafttest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIVersion is the API version reported by the fake server.
+const APIVersion = "v1"
+
+// Request is a request received by the fake Server.
+type Request struct {
+	// Method is the HTTP method.
+	Method string
+	// Path is the request path.
+	Path string
+	// Header contains the request headers.
+	Header http.Header
+	// Body is the decoded JSON request body.
+	Body map[string]interface{}
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLatency delays every response by d.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// WithErrorRate makes a random fraction rate (between 0 and 1) of requests
+// fail with the given status code. Use WithSeed for reproducible failures.
+func WithErrorRate(rate float64, status int) Option {
+	return func(s *Server) {
+		s.errorRate = rate
+		s.errorStatus = status
+	}
+}
+
+// WithSeed seeds the random source behind WithErrorRate.
+func WithSeed(seed int64) Option {
+	return func(s *Server) {
+		s.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithRateLimit allows limit requests per window. Every response carries
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers;
+// once the limit is used up, requests fail with 429 and a Retry-After header
+// until the window resets.
+func WithRateLimit(limit int, window time.Duration) Option {
+	return func(s *Server) {
+		s.rateLimit = limit
+		s.rateWindow = window
+	}
+}
+
+// WithScreenshotFixture serves data with the given content type for every
+// screenshot instead of a generated image.
+func WithScreenshotFixture(data []byte, contentType string) Option {
+	return func(s *Server) {
+		s.screenshotFixture = data
+		s.screenshotType = contentType
+	}
+}
+
+// WithPDFFixture serves data for every PDF instead of the built-in one-page
+// document.
+func WithPDFFixture(data []byte) Option {
+	return func(s *Server) {
+		s.pdfFixture = data
+	}
+}
+
+// Server is a fake ScreenCraft API.
+type Server struct {
+	*httptest.Server
+
+	latency           time.Duration
+	errorRate         float64
+	errorStatus       int
+	rateLimit         int
+	rateWindow        time.Duration
+	screenshotFixture []byte
+	screenshotType    string
+	pdfFixture        []byte
+
+	mu          sync.Mutex
+	rand        *rand.Rand
+	requests    []Request
+	failNext    int
+	failStatus  int
+	windowStart time.Time
+	windowUsed  int
+	sequence    int
+}
+
+// NewServer starts a fake API server. Callers must Close it.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		pdfFixture: PDF(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.rateWindow <= 0 {
+		s.rateWindow = time.Minute
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset clears recorded requests, injected failures and rate limit usage.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.failNext = 0
+	s.windowStart = time.Time{}
+	s.windowUsed = 0
+}
+
+// FailNext makes the next n requests fail with the given status code.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failNext = n
+	s.failStatus = status
+}
+
+// PNG returns a white PNG image of the given size.
+func PNG(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, blank(width, height))
+	return buf.Bytes()
+}
+
+// JPEG returns a white JPEG image of the given size.
+func JPEG(width, height int) []byte {
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, blank(width, height), nil)
+	return buf.Bytes()
+}
+
+// PDF returns a valid, empty one-page A4 PDF document.
+func PDF() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] >>",
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+func blank(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return img
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if s.latency > 0 {
+		select {
+		case <-time.After(s.latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	data, _ := io.ReadAll(r.Body)
+	var body map[string]interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON", "request body must be JSON")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	s.sequence++
+	requestID := fmt.Sprintf("req_%d", s.sequence)
+
+	status := 0
+	if s.failNext > 0 {
+		s.failNext--
+		status = s.failStatus
+	} else if s.errorRate > 0 && s.rand.Float64() < s.errorRate {
+		status = s.errorStatus
+	}
+	limited, retryAfter := s.takeRateLimit(w.Header())
+	s.mu.Unlock()
+
+	w.Header().Set("X-Request-ID", requestID)
+	w.Header().Set("X-API-Version", APIVersion)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") && r.Header.Get("X-API-Key") == "" && r.URL.Query().Get("api_key") == "" {
+		writeError(w, http.StatusUnauthorized, "AUTHENTICATION_ERROR", "missing API key")
+		return
+	}
+	if limited {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeError(w, http.StatusTooManyRequests, "RATE_LIMITED", "rate limit exceeded")
+		return
+	}
+	if status != 0 {
+		writeError(w, status, "INJECTED_FAILURE", http.StatusText(status))
+		return
+	}
+
+	path := r.URL.Path
+	switch {
+	case path == "/health" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"status": "ok", "version": APIVersion},
+		})
+	case r.Method != http.MethodPost:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+	case path != "/screenshots" && path != "/pdfs":
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown endpoint")
+	case body["url"] == nil && body["html"] == nil:
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "url or html is required")
+	case body["webhook"] != nil:
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{
+			"success": true,
+			"jobId":   "job_" + strings.TrimPrefix(requestID, "req_"),
+		})
+	case path == "/screenshots":
+		s.screenshot(w, r, body)
+	default:
+		w.Header().Set("X-PDF-Pages", "1")
+		writeCapture(w, r, s.pdfFixture, "application/pdf")
+	}
+}
+
+// takeRateLimit counts a request against the rate limit window and sets the
+// rate limit headers. It reports whether the request is over the limit and,
+// if so, the seconds until the window resets. s.mu must be held.
+func (s *Server) takeRateLimit(h http.Header) (bool, int) {
+	if s.rateLimit <= 0 {
+		return false, 0
+	}
+
+	now := time.Now()
+	if s.windowStart.IsZero() || now.Sub(s.windowStart) >= s.rateWindow {
+		s.windowStart = now
+		s.windowUsed = 0
+	}
+	reset := s.windowStart.Add(s.rateWindow)
+
+	limited := s.windowUsed >= s.rateLimit
+	if !limited {
+		s.windowUsed++
+	}
+
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(s.rateLimit-s.windowUsed))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	retryAfter := int(reset.Sub(now).Seconds() + 0.999)
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	return limited, retryAfter
+}
+
+func (s *Server) screenshot(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	width, height := 1280, 720
+	if vp, ok := body["viewport"].(map[string]interface{}); ok {
+		width = intValue(vp["width"], width)
+		height = intValue(vp["height"], height)
+	}
+	if clip, ok := body["clip"].(map[string]interface{}); ok {
+		width = intValue(clip["width"], width)
+		height = intValue(clip["height"], height)
+	}
+	if full, _ := body["fullPage"].(bool); full {
+		height *= 3
+	}
+
+	data, contentType := s.screenshotFixture, s.screenshotType
+	if data == nil {
+		switch body["format"] {
+		case "jpeg", "jpg":
+			data, contentType = JPEG(width, height), "image/jpeg"
+		default:
+			data, contentType = PNG(width, height), "image/png"
+		}
+	}
+
+	w.Header().Set("X-Image-Width", strconv.Itoa(width))
+	w.Header().Set("X-Image-Height", strconv.Itoa(height))
+	writeCapture(w, r, data, contentType)
+}
+
+// writeCapture writes a capture with an ETag derived from its data, or 304
+// Not Modified if the request's If-None-Match matches it.
+func writeCapture(w http.ResponseWriter, r *http.Request, data []byte, contentType string) {
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func intValue(v interface{}, def int) int {
+	if f, ok := v.(float64); ok && f > 0 {
+		return int(f)
+	}
+	return def
+}
@@ -2,11 +2,11 @@ package screencraft
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 const (
@@ -31,21 +31,97 @@ const (
 //	    log.Fatal(err)
 //	}
 //	os.WriteFile("screenshot.png", result.Data, 0644)
-func (c *Client) Screenshot(ctx context.Context, opts *ScreenshotOptions) (*ScreenshotResult, error) {
+func (c *Client) Screenshot(ctx context.Context, opts *ScreenshotOptions, callOpts ...CallOption) (*ScreenshotResult, error) {
+	ctx = withCallOptions(ctx, callOpts)
+
 	if err := ValidateScreenshotOptions(opts); err != nil {
 		return nil, err
 	}
 
+	// Captures delivered by webhook or uploaded to storage are never served
+	// from the cache or coalesced
+	var cacheKey, flightKey string
+	if opts.Webhook == nil && opts.Storage == nil {
+		cacheKey = c.cacheKey("screenshot", opts)
+		ctx = c.withRevalidation(ctx, cacheKey)
+		flightKey = c.flightKey(ctx, "screenshot", opts)
+	}
+	if cached, ok := c.cachedResult(cacheKey); ok {
+		if r, ok := cached.(*ScreenshotResult); ok {
+			result := *r
+			result.RetryInfo = RetryInfo{}
+			result.ResponseInfo = ResponseInfo{}
+			result.FromCache = true
+			return &result, nil
+		}
+	}
+
+	if flightKey != "" {
+		v, err := c.flights.do(ctx, flightKey, func(ctx context.Context) (interface{}, error) {
+			return c.captureScreenshot(ctx, opts, cacheKey)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sharedScreenshot(v.(*ScreenshotResult)), nil
+	}
+
+	return c.captureScreenshot(ctx, opts, cacheKey)
+}
+
+// captureScreenshot sends a synchronous capture request and caches its result under
+// cacheKey.
+func (c *Client) captureScreenshot(ctx context.Context, opts *ScreenshotOptions, cacheKey string) (*ScreenshotResult, error) {
 	// Build request body
 	reqBody := c.buildScreenshotRequest(opts)
 
-	resp, err := c.doRequest(ctx, http.MethodPost, screenshotEndpoint, reqBody)
+	start := time.Now()
+	resp, info, err := c.doRequest(ctx, http.MethodPost, screenshotEndpoint, reqBody)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return c.parseScreenshotResponse(resp, opts)
+	if resp.StatusCode == http.StatusNotModified {
+		stale, ok := c.notModifiedResult(cacheKey, callOptionsFrom(ctx).ifNoneMatch)
+		r, isResult := stale.(*ScreenshotResult)
+		if !ok || !isResult {
+			return nil, ErrNotModified
+		}
+
+		stored := *r
+		stored.CacheInfo = revalidatedCacheInfo(r.CacheInfo, resp.Header)
+		c.storeResult(cacheKey, &stored, stored.CacheInfo)
+
+		result := stored
+		result.RetryInfo = info
+		result.ResponseInfo = newResponseInfo(resp, start)
+		result.FromCache = true
+		return &result, nil
+	}
+
+	result, err := c.parseScreenshotResponse(resp, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.ResponseInfo = newResponseInfo(resp, start)
+	if opts.Resize != nil {
+		if err := applyResize(result, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	result.RetryInfo = info
+	result.CacheInfo = cacheInfoFromHeader(resp.Header)
+	result.CapturedAt = time.Now().UTC()
+	result.OptionsHash = OptionsFingerprint(opts)
+
+	if len(result.Data) > 0 {
+		stored := *result
+		c.storeResult(cacheKey, &stored, result.CacheInfo)
+	}
+
+	return result, nil
 }
 
 // ScreenshotAsync captures a screenshot asynchronously using webhooks.
@@ -67,7 +143,9 @@ func (c *Client) Screenshot(ctx context.Context, opts *ScreenshotOptions) (*Scre
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Job ID: %s\n", jobID)
-func (c *Client) ScreenshotAsync(ctx context.Context, opts *ScreenshotOptions) (string, error) {
+func (c *Client) ScreenshotAsync(ctx context.Context, opts *ScreenshotOptions, callOpts ...CallOption) (string, error) {
+	ctx = withCallOptions(ctx, callOpts)
+
 	if err := ValidateScreenshotOptions(opts); err != nil {
 		return "", err
 	}
@@ -76,10 +154,21 @@ func (c *Client) ScreenshotAsync(ctx context.Context, opts *ScreenshotOptions) (
 		return "", NewValidationError("webhook.url", "webhook URL is required for async operations", "required").Error
 	}
 
+	if c.jobs != nil {
+		return c.trackJob(ctx, "screenshot", opts, func(ctx context.Context) (string, error) {
+			return c.screenshotAsync(ctx, opts)
+		})
+	}
+
+	return c.screenshotAsync(ctx, opts)
+}
+
+// screenshotAsync submits an asynchronous capture request and returns its job ID.
+func (c *Client) screenshotAsync(ctx context.Context, opts *ScreenshotOptions) (string, error) {
 	// Build request body
 	reqBody := c.buildScreenshotRequest(opts)
 
-	resp, err := c.doRequest(ctx, http.MethodPost, screenshotEndpoint, reqBody)
+	resp, _, err := c.doRequest(ctx, http.MethodPost, screenshotEndpoint, reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -92,7 +181,7 @@ func (c *Client) ScreenshotAsync(ctx context.Context, opts *ScreenshotOptions) (
 	}
 
 	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := c.decodeJSON(body, &apiResp); err != nil {
 		return "", fmt.Errorf("screencraft: failed to parse response: %w", err)
 	}
 
@@ -116,6 +205,10 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["format"] = opts.Format
 	}
 
+	if len(opts.Formats) > 0 {
+		req["formats"] = opts.Formats
+	}
+
 	if opts.Quality > 0 {
 		req["quality"] = opts.Quality
 	}
@@ -124,6 +217,10 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["fullPage"] = true
 	}
 
+	if opts.OmitBackground {
+		req["omitBackground"] = true
+	}
+
 	if opts.Viewport != nil {
 		viewport := map[string]interface{}{}
 		if opts.Viewport.Width > 0 {
@@ -154,10 +251,42 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		}
 	}
 
+	if opts.Resize != nil {
+		req["resize"] = opts.Resize
+	}
+
+	if opts.Thumbnail != nil {
+		req["thumbnail"] = opts.Thumbnail
+	}
+
 	if opts.AcceptCookies {
 		req["acceptCookies"] = true
 	}
 
+	if len(opts.HideSelectors) > 0 {
+		req["hideSelectors"] = opts.HideSelectors
+	}
+
+	if opts.InjectCSS != "" {
+		req["injectCSS"] = opts.InjectCSS
+	}
+
+	if opts.InjectCSSURL != "" {
+		req["injectCSSURL"] = opts.InjectCSSURL
+	}
+
+	if len(opts.Actions) > 0 {
+		req["actions"] = opts.Actions
+	}
+
+	if opts.Login != nil {
+		req["login"] = opts.Login
+	}
+
+	if opts.BasicAuth != nil {
+		req["basicAuth"] = opts.BasicAuth
+	}
+
 	if opts.Delay > 0 {
 		req["delay"] = opts.Delay
 	}
@@ -170,10 +299,46 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["waitForSelector"] = opts.WaitForSelector
 	}
 
+	if len(opts.WaitForSelectors) > 0 {
+		req["waitForSelectors"] = opts.WaitForSelectors
+		if opts.WaitMode != "" {
+			req["waitMode"] = opts.WaitMode
+		}
+	}
+
 	if opts.WaitForTimeout > 0 {
 		req["waitForTimeout"] = opts.WaitForTimeout
 	}
 
+	if opts.WaitForFunction != "" {
+		waitFn := map[string]interface{}{
+			"expression": opts.WaitForFunction,
+		}
+		if opts.WaitForFunctionTimeout > 0 {
+			waitFn["timeout"] = opts.WaitForFunctionTimeout
+		}
+		req["waitForFunction"] = waitFn
+	}
+
+	if opts.WaitForRequest != "" {
+		req["waitForRequest"] = opts.WaitForRequest
+	}
+
+	if opts.WaitForResponse != "" {
+		req["waitForResponse"] = opts.WaitForResponse
+	}
+
+	if opts.ScrollThrough {
+		scroll := map[string]interface{}{}
+		if opts.ScrollStep > 0 {
+			scroll["step"] = opts.ScrollStep
+		}
+		if opts.ScrollPause > 0 {
+			scroll["pause"] = opts.ScrollPause
+		}
+		req["scrollThrough"] = scroll
+	}
+
 	if len(opts.Cookies) > 0 {
 		req["cookies"] = opts.Cookies
 	}
@@ -186,6 +351,14 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["userAgent"] = opts.UserAgent
 	}
 
+	if opts.Locale != "" {
+		req["locale"] = opts.Locale
+	}
+
+	if opts.Timezone != "" {
+		req["timezone"] = opts.Timezone
+	}
+
 	if opts.DeviceScaleFactor > 0 {
 		req["deviceScaleFactor"] = opts.DeviceScaleFactor
 	}
@@ -206,6 +379,22 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["darkMode"] = true
 	}
 
+	if opts.EmulateMedia != nil {
+		media := map[string]interface{}{}
+		if opts.EmulateMedia.ReducedMotion != "" {
+			media["reducedMotion"] = opts.EmulateMedia.ReducedMotion
+		}
+		if opts.EmulateMedia.ForcedColors != "" {
+			media["forcedColors"] = opts.EmulateMedia.ForcedColors
+		}
+		if opts.EmulateMedia.Contrast != "" {
+			media["contrast"] = opts.EmulateMedia.Contrast
+		}
+		if len(media) > 0 {
+			req["emulateMedia"] = media
+		}
+	}
+
 	if opts.BlockAds {
 		req["blockAds"] = true
 	}
@@ -214,6 +403,10 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["blockTrackers"] = true
 	}
 
+	if len(opts.BlockURLPatterns) > 0 {
+		req["blockURLPatterns"] = opts.BlockURLPatterns
+	}
+
 	if opts.BypassCSP {
 		req["bypassCSP"] = true
 	}
@@ -222,6 +415,42 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 		req["javascript"] = *opts.JavaScript
 	}
 
+	if len(opts.AssertTextPresent) > 0 {
+		req["assertTextPresent"] = opts.AssertTextPresent
+	}
+
+	if len(opts.AssertTextAbsent) > 0 {
+		req["assertTextAbsent"] = opts.AssertTextAbsent
+	}
+
+	if opts.Geolocation != nil {
+		req["geolocation"] = opts.Geolocation
+	}
+
+	if opts.Proxy != nil {
+		req["proxy"] = opts.Proxy
+	}
+
+	if opts.OCR {
+		req["ocr"] = true
+	}
+
+	if opts.Watermark != nil {
+		req["watermark"] = opts.Watermark
+	}
+
+	if opts.Storage != nil {
+		req["storage"] = opts.Storage
+	}
+
+	if opts.Priority != PriorityNormal {
+		req["priority"] = opts.Priority.String()
+	}
+
+	if opts.ClientReference != "" {
+		req["clientReference"] = opts.ClientReference
+	}
+
 	if opts.Webhook != nil {
 		webhook := map[string]interface{}{
 			"url": opts.Webhook.URL,
@@ -238,6 +467,28 @@ func (c *Client) buildScreenshotRequest(opts *ScreenshotOptions) map[string]inte
 	return req
 }
 
+// screenshotResponse is the JSON API response for a screenshot request.
+//
+// Synchronous captures that return additional data alongside the image (such
+// as OCR results) use this envelope with the image base64-encoded.
+type screenshotResponse struct {
+	APIResponse
+	Data *screenshotData `json:"data,omitempty"`
+}
+
+// screenshotData contains the image and its associated data.
+type screenshotData struct {
+	Image       []byte            `json:"image"`
+	Images      map[Format][]byte `json:"images,omitempty"`
+	Thumbnail   []byte            `json:"thumbnail,omitempty"`
+	ContentType string            `json:"contentType"`
+	Width       int               `json:"width,omitempty"`
+	Height      int               `json:"height,omitempty"`
+	TextBlocks  []TextBlock       `json:"textBlocks,omitempty"`
+	Assertions  Assertions        `json:"assertions,omitempty"`
+	Storage     *StoredObject     `json:"storage,omitempty"`
+}
+
 // parseScreenshotResponse parses the screenshot response from the API.
 func (c *Client) parseScreenshotResponse(resp *http.Response, opts *ScreenshotOptions) (*ScreenshotResult, error) {
 	contentType := resp.Header.Get("Content-Type")
@@ -249,8 +500,8 @@ func (c *Client) parseScreenshotResponse(resp *http.Response, opts *ScreenshotOp
 			return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
 		}
 
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err != nil {
+		var apiResp screenshotResponse
+		if err := c.decodeJSON(body, &apiResp); err != nil {
 			return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
 		}
 
@@ -261,6 +512,32 @@ func (c *Client) parseScreenshotResponse(resp *http.Response, opts *ScreenshotOp
 			}
 		}
 
+		// Image with additional data (e.g. OCR results or multiple formats),
+		// or the location of an image uploaded to storage
+		if apiResp.Data != nil {
+			result := &ScreenshotResult{
+				Data:        apiResp.Data.Image,
+				ContentType: apiResp.Data.ContentType,
+				URL:         opts.URL,
+				Width:       apiResp.Data.Width,
+				Height:      apiResp.Data.Height,
+				JobID:       apiResp.JobID,
+				Images:      apiResp.Data.Images,
+				Thumbnail:   apiResp.Data.Thumbnail,
+				TextBlocks:  apiResp.Data.TextBlocks,
+				Assertions:  apiResp.Data.Assertions,
+				Storage:     apiResp.Data.Storage,
+			}
+
+			if len(result.Data) == 0 && len(result.Images) > 0 {
+				primary := primaryFormat(opts)
+				result.Data = result.Images[primary]
+				result.ContentType = primary.ContentType()
+			}
+
+			return result, nil
+		}
+
 		// Async response
 		return &ScreenshotResult{
 			URL:   opts.URL,
@@ -280,6 +557,11 @@ func (c *Client) parseScreenshotResponse(resp *http.Response, opts *ScreenshotOp
 		URL:         opts.URL,
 	}
 
+	// A thumbnail-only capture returns just the thumbnail
+	if opts.Thumbnail != nil && opts.Thumbnail.Only {
+		result.Thumbnail = data
+	}
+
 	// Parse dimension headers if available
 	if w := resp.Header.Get("X-Image-Width"); w != "" {
 		if width, err := strconv.Atoi(w); err == nil {
@@ -293,9 +575,26 @@ func (c *Client) parseScreenshotResponse(resp *http.Response, opts *ScreenshotOp
 		}
 	}
 
+	assertions, err := c.parseAssertionsHeader(resp)
+	if err != nil {
+		return nil, err
+	}
+	result.Assertions = assertions
+
 	return result, nil
 }
 
+// primaryFormat returns the format of ScreenshotResult.Data for a capture.
+func primaryFormat(opts *ScreenshotOptions) Format {
+	if opts.Format != "" {
+		return opts.Format
+	}
+	if len(opts.Formats) > 0 {
+		return opts.Formats[0]
+	}
+	return FormatPNG
+}
+
 // ScreenshotURL captures a screenshot with minimal options.
 //
 // This is a convenience method for simple screenshot captures.
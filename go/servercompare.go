@@ -0,0 +1,152 @@
+package screencraft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	compareEndpoint = "/screenshots/compare"
+)
+
+// CompareOptions represents options for a server-side screenshot comparison.
+// URLA is captured and compared with either a capture of URLB or the result
+// of a previous job (BaselineJobID). Set exactly one of URLB and
+// BaselineJobID.
+type CompareOptions struct {
+	// URLA is the page to capture.
+	URLA string `json:"urlA"`
+	// URLB is the page to compare URLA with.
+	URLB string `json:"urlB,omitempty"`
+	// BaselineJobID is the ID of a completed screenshot job to compare URLA
+	// with.
+	BaselineJobID string `json:"baselineJobId,omitempty"`
+	// Threshold is the mismatch ratio (0-1) above which the captures count
+	// as changed. Defaults to the API's threshold.
+	Threshold float64 `json:"threshold,omitempty"`
+	// Viewport sets the browser viewport dimensions of both captures.
+	Viewport *Viewport `json:"viewport,omitempty"`
+	// FullPage captures the full scrollable pages.
+	FullPage bool `json:"fullPage,omitempty"`
+	// Delay is the time to wait after page load before capture (in milliseconds).
+	Delay int `json:"delay,omitempty"`
+	// AcceptCookies automatically accepts cookie consent banners.
+	AcceptCookies bool `json:"acceptCookies,omitempty"`
+}
+
+// CompareResult represents the result of a server-side comparison.
+type CompareResult struct {
+	RetryInfo `json:"-"`
+
+	// Score is the fraction of differing pixels (0-1).
+	Score float64 `json:"score"`
+	// Changed is true if Score exceeds the threshold.
+	Changed bool `json:"changed"`
+	// DiffImage highlights the differing pixels.
+	DiffImage []byte `json:"diffImage,omitempty"`
+	// ContentType is the MIME type of DiffImage.
+	ContentType string `json:"contentType,omitempty"`
+	// JobID is the ID of the capture of URLA, usable as a future baseline.
+	JobID string `json:"jobId,omitempty"`
+}
+
+// compareResponse is the API response for a comparison request.
+type compareResponse struct {
+	APIResponse
+	Data *CompareResult `json:"data,omitempty"`
+}
+
+// Compare captures opts.URLA and compares it with opts.URLB or a baseline
+// job on the server, returning a diff score and diff image without moving
+// pixel work onto the caller's machines. Use CompareImages to compare
+// captures locally instead.
+//
+// Example:
+//
+//	result, err := client.Compare(ctx, &screencraft.CompareOptions{
+//	    URLA:          "https://example.com",
+//	    BaselineJobID: lastJobID,
+//	    Threshold:     0.01,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if result.Changed {
+//	    os.WriteFile("diff.png", result.DiffImage, 0644)
+//	}
+func (c *Client) Compare(ctx context.Context, opts *CompareOptions) (*CompareResult, error) {
+	if err := ValidateCompareOptions(opts); err != nil {
+		return nil, err
+	}
+
+	resp, info, err := c.doRequest(ctx, http.MethodPost, compareEndpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var compareResp compareResponse
+	if err := c.decodeJSON(body, &compareResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !compareResp.Success || compareResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    compareResp.Message,
+		}
+	}
+
+	result := compareResp.Data
+	if result.ContentType == "" && len(result.DiffImage) > 0 {
+		result.ContentType = FormatPNG.ContentType()
+	}
+	result.RetryInfo = info
+	return result, nil
+}
+
+// ValidateCompareOptions validates comparison options.
+func ValidateCompareOptions(opts *CompareOptions) error {
+	if opts == nil || opts.URLA == "" {
+		return ErrMissingURL
+	}
+
+	if _, err := NormalizeTargetURL(opts.URLA); err != nil {
+		return NewValidationError("urlA", err.Error(), "url").Error
+	}
+
+	if opts.URLB == "" && opts.BaselineJobID == "" {
+		return NewValidationError("urlB", "urlB or baselineJobId is required", "required").Error
+	}
+
+	if opts.URLB != "" && opts.BaselineJobID != "" {
+		return NewValidationError("baselineJobId", "cannot be combined with urlB", "exclusive").Error
+	}
+
+	if opts.URLB != "" {
+		if _, err := NormalizeTargetURL(opts.URLB); err != nil {
+			return NewValidationError("urlB", err.Error(), "url").Error
+		}
+	}
+
+	if opts.Threshold < 0 || opts.Threshold > 1 {
+		return NewValidationError("threshold", "threshold must be between 0 and 1", "range").Error
+	}
+
+	if opts.Viewport != nil && (opts.Viewport.Width < 0 || opts.Viewport.Height < 0) {
+		return ErrInvalidViewport
+	}
+
+	if opts.Delay < 0 {
+		return NewValidationError("delay", "delay must not be negative", "range").Error
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package screencraft
+
+import (
+	"context"
+	"io"
+)
+
+// Capturer captures screenshots and PDFs. Both *Client and *ShardedClient
+// implement it, so code that only captures can depend on Capturer and use a
+// fake in tests.
+//
+// Example:
+//
+//	type Archiver struct {
+//	    Captures screencraft.Capturer
+//	}
+//
+//	archiver := &Archiver{Captures: screencraft.New(apiKey)}
+type Capturer interface {
+	// Screenshot captures a screenshot. See Client.Screenshot.
+	Screenshot(ctx context.Context, opts *ScreenshotOptions, callOpts ...CallOption) (*ScreenshotResult, error)
+	// ScreenshotAsync starts an asynchronous screenshot and returns its job
+	// ID. See Client.ScreenshotAsync.
+	ScreenshotAsync(ctx context.Context, opts *ScreenshotOptions, callOpts ...CallOption) (string, error)
+	// PDF generates a PDF. See Client.PDF.
+	PDF(ctx context.Context, opts *PDFOptions, callOpts ...CallOption) (*PDFResult, error)
+	// PDFAsync starts an asynchronous PDF and returns its job ID. See
+	// Client.PDFAsync.
+	PDFAsync(ctx context.Context, opts *PDFOptions, callOpts ...CallOption) (string, error)
+}
+
+// Service is the API surface of *Client: captures, batches, PDF conversion,
+// page analysis and account information. Depend on Service instead of
+// *Client to substitute a mock or fake in tests.
+type Service interface {
+	Capturer
+
+	// ScreenshotBatch captures screenshots concurrently. See
+	// Client.ScreenshotBatch.
+	ScreenshotBatch(ctx context.Context, items []*ScreenshotOptions, callOpts ...CallOption) []ScreenshotBatchResult
+	// ScreenshotBatchStream captures screenshots concurrently and streams the
+	// results. See Client.ScreenshotBatchStream.
+	ScreenshotBatchStream(ctx context.Context, items []*ScreenshotOptions, callOpts ...CallOption) <-chan ScreenshotBatchResult
+	// PDFBatch generates PDFs concurrently. See Client.PDFBatch.
+	PDFBatch(ctx context.Context, items []*PDFOptions, callOpts ...CallOption) []PDFBatchResult
+	// PDFBatchStream generates PDFs concurrently and streams the results.
+	// See Client.PDFBatchStream.
+	PDFBatchStream(ctx context.Context, items []*PDFOptions, callOpts ...CallOption) <-chan PDFBatchResult
+	// ScreenshotAndUpload captures a screenshot and uploads it. See
+	// Client.ScreenshotAndUpload.
+	ScreenshotAndUpload(ctx context.Context, opts *ScreenshotOptions, uploader Uploader, callOpts ...CallOption) (*UploadResult, error)
+	// PDFToImages renders the pages of a PDF as images. See
+	// Client.PDFToImages.
+	PDFToImages(ctx context.Context, source PDFSource, opts *PDFToImagesOptions) (*PDFImagesResult, error)
+	// Audit audits a page. See Client.Audit.
+	Audit(ctx context.Context, opts *AuditOptions) (*AuditResult, error)
+	// Compare compares captures on the server. See Client.Compare.
+	Compare(ctx context.Context, opts *CompareOptions) (*CompareResult, error)
+	// Links extracts the links of a page. See Client.Links.
+	Links(ctx context.Context, pageURL string, opts *LinksOptions) (*LinksResult, error)
+	// StreamJobEvents streams the progress events of an async job. See
+	// Client.StreamJobEvents.
+	StreamJobEvents(ctx context.Context, jobID string) (<-chan JobEvent, error)
+	// DownloadJobResult downloads the result of an async job. See
+	// Client.DownloadJobResult.
+	DownloadJobResult(ctx context.Context, resultURL string, w io.Writer) (int64, error)
+	// ResumePendingJobs resubmits interrupted async jobs and returns those
+	// in flight. See Client.ResumePendingJobs.
+	ResumePendingJobs(ctx context.Context, store JobStore) ([]*JobRecord, error)
+	// CreateSchedule creates a recurring capture. See Client.CreateSchedule.
+	CreateSchedule(ctx context.Context, schedule *Schedule) (*Schedule, error)
+	// GetSchedule returns a schedule. See Client.GetSchedule.
+	GetSchedule(ctx context.Context, id string) (*Schedule, error)
+	// ListSchedules returns the account's schedules. See
+	// Client.ListSchedules.
+	ListSchedules(ctx context.Context) ([]*Schedule, error)
+	// PauseSchedule pauses a schedule. See Client.PauseSchedule.
+	PauseSchedule(ctx context.Context, id string) (*Schedule, error)
+	// ResumeSchedule resumes a paused schedule. See Client.ResumeSchedule.
+	ResumeSchedule(ctx context.Context, id string) (*Schedule, error)
+	// DeleteSchedule deletes a schedule. See Client.DeleteSchedule.
+	DeleteSchedule(ctx context.Context, id string) error
+	// Usage returns the current billing period's usage. See Client.Usage.
+	Usage(ctx context.Context) (*Usage, error)
+	// Account returns the account's plan and entitlements. See
+	// Client.Account.
+	Account(ctx context.Context) (*Account, error)
+	// Ping checks that the API is reachable. See Client.Ping.
+	Ping(ctx context.Context) (*PingResult, error)
+}
+
+var (
+	_ Service  = (*Client)(nil)
+	_ Capturer = (*ShardedClient)(nil)
+)
@@ -0,0 +1,144 @@
+package screencraft
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// shardReplicas is the number of points each client occupies on the hash ring.
+const shardReplicas = 128
+
+// ErrNoShards is returned when a ShardedClient is created without clients.
+var ErrNoShards = errors.New("screencraft: at least one client is required")
+
+// ShardedClient fans requests out across multiple clients.
+//
+// Requests are assigned to clients by consistent hashing on the target URL,
+// so captures of the same URL always go through the same client while load is
+// spread evenly. Each client can use its own transport, API key or region.
+//
+// Example:
+//
+//	sharded, err := screencraft.NewShardedClient(
+//	    screencraft.New(key1, screencraft.WithBaseURL(euURL)),
+//	    screencraft.New(key2, screencraft.WithBaseURL(usURL)),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := sharded.Screenshot(ctx, opts)
+type ShardedClient struct {
+	clients []*Client
+	ring    []uint32
+	owners  map[uint32]int
+}
+
+// NewShardedClient creates a ShardedClient over the given clients.
+func NewShardedClient(clients ...*Client) (*ShardedClient, error) {
+	if len(clients) == 0 {
+		return nil, ErrNoShards
+	}
+
+	s := &ShardedClient{
+		clients: clients,
+		owners:  make(map[uint32]int, len(clients)*shardReplicas),
+	}
+
+	for i := range clients {
+		for r := 0; r < shardReplicas; r++ {
+			point := hashKey(strconv.Itoa(i) + "#" + strconv.Itoa(r))
+			if _, taken := s.owners[point]; taken {
+				continue
+			}
+			s.owners[point] = i
+			s.ring = append(s.ring, point)
+		}
+	}
+	sort.Slice(s.ring, func(i, j int) bool { return s.ring[i] < s.ring[j] })
+
+	return s, nil
+}
+
+// NewShardedClientFromKeys creates a ShardedClient with one client per API
+// key, all configured with the given options.
+func NewShardedClientFromKeys(apiKeys []string, opts ...Option) (*ShardedClient, error) {
+	clients := make([]*Client, len(apiKeys))
+	for i, key := range apiKeys {
+		clients[i] = New(key, opts...)
+	}
+	return NewShardedClient(clients...)
+}
+
+// Clients returns the underlying clients.
+func (s *ShardedClient) Clients() []*Client {
+	return s.clients
+}
+
+// ShardFor returns the client responsible for the given target URL. URLs
+// that normalize to the same form (see NormalizeTargetURL) share a client.
+func (s *ShardedClient) ShardFor(targetURL string) *Client {
+	h := hashKey(normalizedURL(targetURL))
+	i := sort.Search(len(s.ring), func(i int) bool { return s.ring[i] >= h })
+	if i == len(s.ring) {
+		i = 0
+	}
+	return s.clients[s.owners[s.ring[i]]]
+}
+
+// Screenshot captures a screenshot using the client responsible for opts.URL.
+func (s *ShardedClient) Screenshot(ctx context.Context, opts *ScreenshotOptions, callOpts ...CallOption) (*ScreenshotResult, error) {
+	if opts == nil {
+		return nil, ErrMissingURL
+	}
+	return s.ShardFor(opts.URL).Screenshot(ctx, opts, callOpts...)
+}
+
+// ScreenshotAsync captures a screenshot asynchronously using the client
+// responsible for opts.URL.
+func (s *ShardedClient) ScreenshotAsync(ctx context.Context, opts *ScreenshotOptions, callOpts ...CallOption) (string, error) {
+	if opts == nil {
+		return "", ErrMissingURL
+	}
+	return s.ShardFor(opts.URL).ScreenshotAsync(ctx, opts, callOpts...)
+}
+
+// PDF generates a PDF using the client responsible for opts.URL (or the
+// first of opts.URLs).
+func (s *ShardedClient) PDF(ctx context.Context, opts *PDFOptions, callOpts ...CallOption) (*PDFResult, error) {
+	if opts == nil {
+		return nil, ErrMissingURL
+	}
+	return s.ShardFor(pdfTargetURL(opts)).PDF(ctx, opts, callOpts...)
+}
+
+// PDFAsync generates a PDF asynchronously using the client responsible for
+// opts.URL.
+func (s *ShardedClient) PDFAsync(ctx context.Context, opts *PDFOptions, callOpts ...CallOption) (string, error) {
+	if opts == nil {
+		return "", ErrMissingURL
+	}
+	return s.ShardFor(pdfTargetURL(opts)).PDFAsync(ctx, opts, callOpts...)
+}
+
+// Audit runs a performance audit using the client responsible for opts.URL.
+func (s *ShardedClient) Audit(ctx context.Context, opts *AuditOptions) (*AuditResult, error) {
+	if opts == nil {
+		return nil, ErrMissingURL
+	}
+	return s.ShardFor(opts.URL).Audit(ctx, opts)
+}
+
+// Links extracts links using the client responsible for pageURL.
+func (s *ShardedClient) Links(ctx context.Context, pageURL string, opts *LinksOptions) (*LinksResult, error) {
+	return s.ShardFor(pageURL).Links(ctx, pageURL, opts)
+}
+
+// hashKey returns the ring position of a key.
+func hashKey(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
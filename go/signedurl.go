@@ -0,0 +1,135 @@
+package screencraft
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	signedScreenshotEndpoint = "/screenshots/signed"
+)
+
+// ErrMissingSigningKey is returned when a signed URL is requested from a
+// client without a URL signing key.
+var ErrMissingSigningKey = errors.New("screencraft: URL signing key is required")
+
+// WithURLSigningKey sets the key used to sign capture URLs. The key ID is
+// public and included in each URL; the secret never leaves the client.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey,
+//	    screencraft.WithURLSigningKey(os.Getenv("SCREENCRAFT_KEY_ID"), os.Getenv("SCREENCRAFT_SIGNING_SECRET")),
+//	)
+func WithURLSigningKey(keyID, secret string) Option {
+	return func(c *Client) {
+		c.signingKeyID = keyID
+		c.signingSecret = secret
+	}
+}
+
+// SignedScreenshotURL returns a GET URL that renders the screenshot described
+// by opts when fetched, e.g. from an <img src> attribute. The URL is signed
+// with the client's URL signing key and stops working after expiry.
+//
+// Options that carry credentials (basic auth, login, proxy passwords, cookies
+// and headers) and webhooks cannot be used, since the URL is visible to
+// whoever loads it.
+//
+// Example:
+//
+//	src, err := client.SignedScreenshotURL(&screencraft.ScreenshotOptions{
+//	    URL:    "https://example.com",
+//	    Format: screencraft.FormatWebP,
+//	}, time.Hour)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Fprintf(w, `<img src="%s">`, html.EscapeString(src))
+func (c *Client) SignedScreenshotURL(opts *ScreenshotOptions, expiry time.Duration) (string, error) {
+	if err := ValidateScreenshotOptions(opts); err != nil {
+		return "", err
+	}
+
+	if err := validateSignedOptions(opts); err != nil {
+		return "", err
+	}
+
+	if expiry <= 0 {
+		return "", NewValidationError("expiry", "must be positive", "range").Error
+	}
+
+	c.mu.RLock()
+	keyID, secret := c.signingKeyID, c.signingSecret
+	c.mu.RUnlock()
+
+	if keyID == "" || secret == "" {
+		return "", ErrMissingSigningKey
+	}
+
+	query, err := signedQuery(c.buildScreenshotRequest(opts))
+	if err != nil {
+		return "", err
+	}
+	query.Set("keyId", keyID)
+	query.Set("expires", strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+
+	path := c.endpointPath(signedScreenshotEndpoint)
+	query.Set("signature", signURL(path, query, secret))
+
+	return c.versionedBaseURL(c.BaseURL()) + path + "?" + query.Encode(), nil
+}
+
+// validateSignedOptions rejects options that must not be exposed in a URL.
+func validateSignedOptions(opts *ScreenshotOptions) error {
+	switch {
+	case opts.Webhook != nil:
+		return NewValidationError("webhook", "not supported in signed URLs", "exclusive").Error
+	case opts.BasicAuth != nil:
+		return NewValidationError("basicAuth", "credentials cannot be embedded in signed URLs", "exclusive").Error
+	case opts.Login != nil:
+		return NewValidationError("login", "credentials cannot be embedded in signed URLs", "exclusive").Error
+	case opts.Proxy != nil && opts.Proxy.Password != "":
+		return NewValidationError("proxy.password", "credentials cannot be embedded in signed URLs", "exclusive").Error
+	case len(opts.Cookies) > 0:
+		return NewValidationError("cookies", "cannot be embedded in signed URLs", "exclusive").Error
+	case len(opts.Headers) > 0:
+		return NewValidationError("headers", "cannot be embedded in signed URLs", "exclusive").Error
+	}
+	return nil
+}
+
+// signedQuery converts a request body to query parameters. Strings and
+// numbers are passed as-is; objects and arrays are JSON-encoded.
+func signedQuery(body map[string]interface{}) (url.Values, error) {
+	query := url.Values{}
+	for key, value := range body {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("screencraft: failed to encode %s: %w", key, err)
+		}
+
+		var s string
+		if json.Unmarshal(data, &s) == nil {
+			query.Set(key, s)
+		} else {
+			query.Set(key, string(data))
+		}
+	}
+	return query, nil
+}
+
+// signURL returns the hex-encoded HMAC-SHA256 of the path and the sorted,
+// encoded query parameters.
+func signURL(path string, query url.Values, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(path + "?" + query.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,64 @@
+package screencraft
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithSlog logs the client's activity to logger as structured records.
+//
+// Each attempt is logged with its method, endpoint, attempt number, status,
+// request ID and duration: successful attempts at Debug, failed attempts at
+// Warn and retries at Info. Messages that WithDebug would print are logged at
+// Debug. The handler's level decides what is emitted; WithDebug is not
+// required.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithSlog(slog.Default()))
+func WithSlog(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.slog = logger
+	}
+}
+
+// logAttempt logs the outcome of an attempt.
+func (c *Client) logAttempt(ctx context.Context, method, endpoint string, attempt, statusCode int, requestID string, duration time.Duration, err error) {
+	if c.slog == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.Int("attempt", attempt),
+		slog.Int("status", statusCode),
+		slog.Duration("duration", duration),
+	}
+	if requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", c.redactString(err.Error())))
+		c.slog.LogAttrs(ctx, slog.LevelWarn, "screencraft: request failed", attrs...)
+		return
+	}
+	c.slog.LogAttrs(ctx, slog.LevelDebug, "screencraft: request completed", attrs...)
+}
+
+// logRetry logs a retry decision.
+func (c *Client) logRetry(ctx context.Context, method, endpoint string, attempt int, wait time.Duration, err error) {
+	if c.slog == nil {
+		return
+	}
+
+	c.slog.LogAttrs(ctx, slog.LevelInfo, "screencraft: retrying request",
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.Int("attempt", attempt),
+		slog.Duration("wait", wait),
+		slog.String("error", c.redactString(err.Error())),
+	)
+}
@@ -0,0 +1,162 @@
+package screencraft
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// TimeoutPhase identifies the stage of a request that timed out.
+type TimeoutPhase string
+
+const (
+	// PhaseDNS is the DNS lookup of the API host.
+	PhaseDNS TimeoutPhase = "dns"
+	// PhaseConnect is establishing the TCP connection (or waiting for an idle one).
+	PhaseConnect TimeoutPhase = "connect"
+	// PhaseTLS is the TLS handshake.
+	PhaseTLS TimeoutPhase = "tls"
+	// PhaseFirstByte is waiting for the first response byte after sending the request.
+	PhaseFirstByte TimeoutPhase = "first_byte"
+	// PhaseReadBody is reading the response body.
+	PhaseReadBody TimeoutPhase = "read_body"
+	// PhaseNavigation is the render-side navigation to the target page, as
+	// reported by the API.
+	PhaseNavigation TimeoutPhase = "navigation"
+)
+
+// PhaseTimings records how long each completed phase of a request took.
+type PhaseTimings struct {
+	// DNS is the duration of the DNS lookup.
+	DNS time.Duration
+	// Connect is the duration of the TCP connect.
+	Connect time.Duration
+	// TLS is the duration of the TLS handshake.
+	TLS time.Duration
+	// FirstByte is the time from writing the request to the first response byte.
+	FirstByte time.Duration
+}
+
+// requestTrace tracks the progress of a single HTTP attempt.
+type requestTrace struct {
+	mu sync.Mutex
+
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+// newRequestTrace starts tracing an attempt.
+func newRequestTrace() *requestTrace {
+	return &requestTrace{start: time.Now()}
+}
+
+// withTrace returns a context that records the attempt's progress in t.
+func (t *requestTrace) withTrace(ctx context.Context) context.Context {
+	mark := func(field *time.Time) {
+		t.mu.Lock()
+		*field = time.Now()
+		t.mu.Unlock()
+	}
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { mark(&t.dnsDone) },
+		ConnectStart:         func(string, string) { mark(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { mark(&t.connectDone) },
+		TLSHandshakeStart:    func() { mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { mark(&t.tlsDone) },
+		GotConn:              func(httptrace.GotConnInfo) { mark(&t.gotConn) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { mark(&t.firstByte) },
+	})
+}
+
+// phase returns the phase the attempt is currently in.
+func (t *requestTrace) phase() TimeoutPhase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case !t.firstByte.IsZero():
+		return PhaseReadBody
+	case !t.gotConn.IsZero():
+		return PhaseFirstByte
+	case !t.tlsStart.IsZero() && t.tlsDone.IsZero():
+		return PhaseTLS
+	case !t.dnsStart.IsZero() && t.dnsDone.IsZero():
+		return PhaseDNS
+	default:
+		return PhaseConnect
+	}
+}
+
+// timings returns the durations of the completed phases.
+func (t *requestTrace) timings() PhaseTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span := func(start, end time.Time) time.Duration {
+		if start.IsZero() || end.IsZero() {
+			return 0
+		}
+		return end.Sub(start)
+	}
+
+	return PhaseTimings{
+		DNS:       span(t.dnsStart, t.dnsDone),
+		Connect:   span(t.connectStart, t.connectDone),
+		TLS:       span(t.tlsStart, t.tlsDone),
+		FirstByte: span(t.wroteRequest, t.firstByte),
+	}
+}
+
+// timeoutError converts err into a TimeoutError for the current phase if it
+// is a timeout, and returns nil otherwise.
+func (t *requestTrace) timeoutError(err error) *TimeoutError {
+	if !isTimeout(err) {
+		return nil
+	}
+
+	timeoutErr := newPhaseTimeoutError(t.phase(), time.Since(t.start), err)
+	timeoutErr.Timings = t.timings()
+	return timeoutErr
+}
+
+// isTimeout reports whether err is a deadline or I/O timeout.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// tracedBody reports timeouts while reading a response body as TimeoutErrors.
+type tracedBody struct {
+	io.ReadCloser
+	trace *requestTrace
+}
+
+// Read implements io.Reader.
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		if timeoutErr := b.trace.timeoutError(err); timeoutErr != nil {
+			return n, timeoutErr
+		}
+	}
+	return n, err
+}
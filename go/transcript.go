@@ -0,0 +1,132 @@
+package screencraft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// transcriptMaxString is the length above which JSON strings, such as
+// base64-encoded documents, are elided from transcripts.
+const transcriptMaxString = 1024
+
+// WithTranscript writes a transcript of every attempt to w: the request line,
+// headers and JSON body, and the response status and headers, or the error.
+// Credentials such as the API key, cookies and passwords are redacted (see
+// WithRedaction) and response bodies are not recorded, so transcripts can be
+// attached to support tickets. Writes are serialized, so w need not be safe
+// for concurrent use.
+//
+// Example:
+//
+//	f, err := os.Create("screencraft-transcript.txt")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	client := screencraft.New(apiKey, screencraft.WithTranscript(f))
+func WithTranscript(w io.Writer) Option {
+	return func(c *Client) {
+		if w == nil {
+			c.transcript = nil
+			return
+		}
+		c.transcript = &transcript{w: w}
+	}
+}
+
+// transcript serializes transcript entries to a writer.
+type transcript struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// recordTranscript writes an attempt to the client's transcript, if any.
+func (c *Client) recordTranscript(req *http.Request, body []byte, attempt int, resp *http.Response, latency time.Duration, err error) {
+	if c.transcript == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	// The URL carries the API key with AuthQueryParam
+	fmt.Fprintf(&buf, "=== %s %s (attempt %d) at %s\n", req.Method, c.redactString(req.URL.String()), attempt, time.Now().UTC().Format(time.RFC3339))
+	writeTranscriptHeaders(&buf, "> ", c.redactHeader(req.Header))
+	if len(body) > 0 {
+		buf.WriteString("\n")
+		buf.Write(c.transcriptBody(body))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+
+	if resp != nil {
+		fmt.Fprintf(&buf, "< %s in %s\n", resp.Status, latency)
+		writeTranscriptHeaders(&buf, "< ", c.redactHeader(resp.Header))
+	}
+	if err != nil {
+		fmt.Fprintf(&buf, "! %s (after %s)\n", c.redactString(err.Error()), latency)
+	}
+	buf.WriteString("\n")
+
+	c.transcript.mu.Lock()
+	defer c.transcript.mu.Unlock()
+	c.transcript.w.Write(buf.Bytes())
+}
+
+// writeTranscriptHeaders writes headers in sorted order with a prefix.
+func writeTranscriptHeaders(buf *bytes.Buffer, prefix string, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range h[name] {
+			fmt.Fprintf(buf, "%s%s: %s\n", prefix, name, value)
+		}
+	}
+}
+
+// transcriptBody returns a redacted, indented request body with long
+// strings elided.
+func (c *Client) transcriptBody(body []byte) []byte {
+	if !c.noRedaction {
+		body = redactJSON(body)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []byte(fmt.Sprintf("[%d bytes of non-JSON body]", len(body)))
+	}
+
+	out, err := json.MarshalIndent(elideLongStrings(v), "", "  ")
+	if err != nil {
+		return []byte(fmt.Sprintf("[%d bytes]", len(body)))
+	}
+	return out
+}
+
+// elideLongStrings replaces strings longer than transcriptMaxString in a
+// decoded JSON value with a placeholder.
+func elideLongStrings(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if len(v) > transcriptMaxString {
+			return fmt.Sprintf("[%d bytes elided]", len(v))
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = elideLongStrings(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = elideLongStrings(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,93 @@
+package screencraft
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// TransportOptions tunes the connection pooling of the client's HTTP
+// transport. Zero values keep the transport's defaults.
+type TransportOptions struct {
+	// MaxIdleConns is the maximum number of idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the total number of connections per host.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout is the maximum time to wait for a TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout is the maximum time to wait for response headers
+	// after the request was written.
+	ResponseHeaderTimeout time.Duration
+	// DisableKeepAlives opens a new connection for every request.
+	DisableKeepAlives bool
+	// DisableHTTP2 restricts the transport to HTTP/1.1.
+	DisableHTTP2 bool
+}
+
+// WithTransportOptions tunes the HTTP transport without replacing the HTTP
+// client. It applies to the default transport, or to a copy of the
+// *http.Transport of a client set with WithHTTPClient; other
+// http.RoundTripper implementations are left unchanged.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithTransportOptions(screencraft.TransportOptions{
+//	    MaxIdleConnsPerHost: 32,
+//	    IdleConnTimeout:     90 * time.Second,
+//	}))
+func WithTransportOptions(opts TransportOptions) Option {
+	return func(c *Client) {
+		var base *http.Transport
+		switch t := c.httpClient.Transport.(type) {
+		case nil:
+			base, _ = http.DefaultTransport.(*http.Transport)
+		case *http.Transport:
+			base = t
+		}
+		if base == nil {
+			c.logf("WithTransportOptions: custom transport %T left unchanged", c.httpClient.Transport)
+			return
+		}
+
+		transport := base.Clone()
+		applyTransportOptions(transport, opts)
+
+		// Copy the client so a client passed to WithHTTPClient is not modified
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+// applyTransportOptions sets the non-zero options on t.
+func applyTransportOptions(t *http.Transport, opts TransportOptions) {
+	if opts.MaxIdleConns > 0 {
+		t.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = opts.MaxConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		t.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+	if opts.ResponseHeaderTimeout > 0 {
+		t.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	}
+	if opts.DisableKeepAlives {
+		t.DisableKeepAlives = true
+	}
+	if opts.DisableHTTP2 {
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
@@ -2,7 +2,10 @@
 // It enables screenshot capture and PDF generation from web pages.
 package screencraft
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 // Format represents the output format for screenshots.
 type Format string
@@ -72,6 +75,126 @@ type Clip struct {
 	Height int `json:"height"`
 }
 
+// Fit controls how an image is resized to target dimensions.
+type Fit string
+
+const (
+	// FitCover fills the target box, cropping what overflows.
+	FitCover Fit = "cover"
+	// FitContain fits the image inside the target box, keeping its aspect ratio.
+	FitContain Fit = "contain"
+	// FitFill stretches the image to the exact target dimensions.
+	FitFill Fit = "fill"
+)
+
+// ThumbnailOptions requests a pre-resized thumbnail of a screenshot.
+type ThumbnailOptions struct {
+	// Width is the thumbnail width in pixels. If zero, it follows from
+	// Height and the aspect ratio.
+	Width int `json:"width,omitempty"`
+	// Height is the thumbnail height in pixels. If zero, it follows from
+	// Width and the aspect ratio.
+	Height int `json:"height,omitempty"`
+	// Fit controls how the capture is fitted to the thumbnail (default FitCover).
+	Fit Fit `json:"fit,omitempty"`
+	// Only returns just the thumbnail instead of the full capture.
+	Only bool `json:"only,omitempty"`
+}
+
+// ResizeAlgorithm selects the resampling algorithm of a resize.
+type ResizeAlgorithm string
+
+const (
+	// ResizeLanczos uses Lanczos resampling (sharpest, the default). Local
+	// fallback resizes approximate it with area averaging.
+	ResizeLanczos ResizeAlgorithm = "lanczos"
+	// ResizeBilinear uses bilinear interpolation.
+	ResizeBilinear ResizeAlgorithm = "bilinear"
+	// ResizeNearest uses nearest-neighbor sampling, e.g. for pixel art.
+	ResizeNearest ResizeAlgorithm = "nearest"
+)
+
+// ResizeOptions normalizes the output dimensions of a screenshot.
+type ResizeOptions struct {
+	// Width is the output width in pixels. If zero, it follows from Height
+	// and the aspect ratio.
+	Width int `json:"width,omitempty"`
+	// Height is the output height in pixels. If zero, it follows from Width
+	// and the aspect ratio.
+	Height int `json:"height,omitempty"`
+	// Fit controls how the capture is fitted to the dimensions (default FitCover).
+	Fit Fit `json:"fit,omitempty"`
+	// Algorithm is the resampling algorithm (default ResizeLanczos).
+	Algorithm ResizeAlgorithm `json:"algorithm,omitempty"`
+}
+
+// WatermarkPosition is where a watermark is placed on the output.
+type WatermarkPosition string
+
+const (
+	// WatermarkCenter centers the watermark.
+	WatermarkCenter WatermarkPosition = "center"
+	// WatermarkTopLeft places the watermark in the top-left corner.
+	WatermarkTopLeft WatermarkPosition = "top-left"
+	// WatermarkTopRight places the watermark in the top-right corner.
+	WatermarkTopRight WatermarkPosition = "top-right"
+	// WatermarkBottomLeft places the watermark in the bottom-left corner.
+	WatermarkBottomLeft WatermarkPosition = "bottom-left"
+	// WatermarkBottomRight places the watermark in the bottom-right corner.
+	WatermarkBottomRight WatermarkPosition = "bottom-right"
+	// WatermarkTile repeats the watermark across the output.
+	WatermarkTile WatermarkPosition = "tile"
+)
+
+// WatermarkOptions stamps a text or image overlay on the output. Exactly
+// one of Text and ImageURL must be set.
+type WatermarkOptions struct {
+	// Text is the watermark text, e.g. "CONFIDENTIAL".
+	Text string `json:"text,omitempty"`
+	// ImageURL is the URL of a watermark image, e.g. a logo.
+	ImageURL string `json:"imageUrl,omitempty"`
+	// Position is where the watermark is placed (default WatermarkCenter).
+	Position WatermarkPosition `json:"position,omitempty"`
+	// Opacity is the watermark opacity (0-1). The API default applies if zero.
+	Opacity float64 `json:"opacity,omitempty"`
+}
+
+// StorageProvider is a cloud storage service the API can upload results to.
+type StorageProvider string
+
+const (
+	// StorageS3 uploads to an Amazon S3 bucket.
+	StorageS3 StorageProvider = "s3"
+)
+
+// StorageConfig uploads the output directly to a bucket instead of returning
+// it in the response. The API must be granted write access to the bucket.
+type StorageConfig struct {
+	// Provider is the storage service.
+	Provider StorageProvider `json:"provider"`
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Key is the object key. The API generates one if empty.
+	Key string `json:"key,omitempty"`
+	// Region is the bucket region, e.g. "eu-central-1". The API default
+	// applies if empty.
+	Region string `json:"region,omitempty"`
+	// ACL is the canned ACL of the object, e.g. "public-read".
+	ACL string `json:"acl,omitempty"`
+}
+
+// StoredObject describes a result uploaded to storage.
+type StoredObject struct {
+	// Provider is the storage service.
+	Provider StorageProvider `json:"provider"`
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Key is the object key.
+	Key string `json:"key"`
+	// URL is the URL of the object.
+	URL string `json:"url"`
+}
+
 // Cookie represents a browser cookie to set before navigation.
 type Cookie struct {
 	// Name is the cookie name.
@@ -114,6 +237,119 @@ const (
 	WaitNetworkIdle0 WaitUntil = "networkidle0"
 )
 
+// WaitMode controls how multiple selector waits are combined.
+type WaitMode string
+
+const (
+	// WaitModeAll waits until all selectors are present.
+	WaitModeAll WaitMode = "all"
+	// WaitModeAny waits until any of the selectors is present.
+	WaitModeAny WaitMode = "any"
+)
+
+// ReducedMotion represents the emulated prefers-reduced-motion media feature.
+type ReducedMotion string
+
+const (
+	// ReducedMotionReduce emulates prefers-reduced-motion: reduce.
+	ReducedMotionReduce ReducedMotion = "reduce"
+	// ReducedMotionNoPreference emulates prefers-reduced-motion: no-preference.
+	ReducedMotionNoPreference ReducedMotion = "no-preference"
+)
+
+// ForcedColors represents the emulated forced-colors media feature.
+type ForcedColors string
+
+const (
+	// ForcedColorsActive emulates forced-colors: active.
+	ForcedColorsActive ForcedColors = "active"
+	// ForcedColorsNone emulates forced-colors: none.
+	ForcedColorsNone ForcedColors = "none"
+)
+
+// Contrast represents the emulated prefers-contrast media feature.
+type Contrast string
+
+const (
+	// ContrastMore emulates prefers-contrast: more.
+	ContrastMore Contrast = "more"
+	// ContrastLess emulates prefers-contrast: less.
+	ContrastLess Contrast = "less"
+	// ContrastCustom emulates prefers-contrast: custom.
+	ContrastCustom Contrast = "custom"
+	// ContrastNoPreference emulates prefers-contrast: no-preference.
+	ContrastNoPreference Contrast = "no-preference"
+)
+
+// EmulateMedia represents CSS media features to emulate during capture.
+// Empty fields leave the browser default unchanged.
+type EmulateMedia struct {
+	// ReducedMotion emulates prefers-reduced-motion.
+	ReducedMotion ReducedMotion `json:"reducedMotion,omitempty"`
+	// ForcedColors emulates forced-colors.
+	ForcedColors ForcedColors `json:"forcedColors,omitempty"`
+	// Contrast emulates prefers-contrast.
+	Contrast Contrast `json:"contrast,omitempty"`
+}
+
+// Credentials represents a username and password.
+type Credentials struct {
+	// Username is the login name.
+	Username string `json:"username"`
+	// Password is the password.
+	Password string `json:"password"`
+}
+
+// LoginConfig describes a scripted login performed before navigating to the
+// target URL.
+type LoginConfig struct {
+	// URL is the login page URL.
+	URL string `json:"url"`
+	// UsernameSelector is the CSS selector of the username input.
+	UsernameSelector string `json:"usernameSelector"`
+	// PasswordSelector is the CSS selector of the password input.
+	PasswordSelector string `json:"passwordSelector"`
+	// SubmitSelector is the CSS selector of the submit button.
+	SubmitSelector string `json:"submitSelector"`
+	// Credentials are the login credentials.
+	Credentials Credentials `json:"credentials"`
+	// SuccessSelector optionally waits for an element confirming the login.
+	SuccessSelector string `json:"successSelector,omitempty"`
+}
+
+// BasicAuth represents HTTP Basic Auth credentials sent to the target site.
+type BasicAuth struct {
+	// Username is the user name. It must not contain a colon.
+	Username string `json:"username"`
+	// Password is the password.
+	Password string `json:"password"`
+}
+
+// ProxyConfig routes a capture through a proxy.
+//
+// Set URL to use your own proxy, or Country to use a ScreenCraft proxy in
+// that region.
+type ProxyConfig struct {
+	// URL is the proxy URL (http, https or socks5 scheme).
+	URL string `json:"url,omitempty"`
+	// Username is the proxy user name, if the proxy requires authentication.
+	Username string `json:"username,omitempty"`
+	// Password is the proxy password.
+	Password string `json:"password,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the exit country, e.g. "DE".
+	Country string `json:"country,omitempty"`
+}
+
+// Geolocation represents an emulated device location.
+type Geolocation struct {
+	// Latitude in degrees (-90 to 90).
+	Latitude float64 `json:"latitude"`
+	// Longitude in degrees (-180 to 180).
+	Longitude float64 `json:"longitude"`
+	// Accuracy is the location accuracy in meters.
+	Accuracy float64 `json:"accuracy,omitempty"`
+}
+
 // WebhookConfig represents webhook configuration for async operations.
 type WebhookConfig struct {
 	// URL is the webhook endpoint to call when the operation completes.
@@ -130,32 +366,84 @@ type ScreenshotOptions struct {
 	URL string `json:"url"`
 	// Format is the output image format (png, jpeg, webp).
 	Format Format `json:"format,omitempty"`
+	// Formats requests several formats of the same render, so the page is
+	// only loaded once. ScreenshotResult.Images contains each format.
+	Formats []Format `json:"formats,omitempty"`
 	// Quality is the image quality (0-100), applicable for JPEG and WebP.
 	Quality int `json:"quality,omitempty"`
 	// FullPage captures the full scrollable page if true.
 	FullPage bool `json:"fullPage,omitempty"`
+	// OmitBackground hides the default white background to capture with
+	// transparency. Requires PNG or WebP format.
+	OmitBackground bool `json:"omitBackground,omitempty"`
 	// Viewport sets the browser viewport dimensions.
 	Viewport *Viewport `json:"viewport,omitempty"`
 	// ScrollPosition sets the scroll position before capture.
 	ScrollPosition *ScrollPosition `json:"scrollPosition,omitempty"`
 	// Clip defines a rectangular region to clip.
 	Clip *Clip `json:"clip,omitempty"`
+	// Resize scales the capture to fixed output dimensions. It is applied
+	// by the API, or locally if the API returned the original size.
+	Resize *ResizeOptions `json:"resize,omitempty"`
+	// Thumbnail requests a pre-resized thumbnail alongside (or instead of)
+	// the full capture.
+	Thumbnail *ThumbnailOptions `json:"thumbnail,omitempty"`
 	// AcceptCookies automatically accepts cookie consent banners.
 	AcceptCookies bool `json:"acceptCookies,omitempty"`
+	// HideSelectors lists CSS selectors of elements to hide before capture
+	// (e.g., chat widgets or sticky headers).
+	HideSelectors []string `json:"hideSelectors,omitempty"`
+	// InjectCSS is a stylesheet applied after page load, before capture.
+	InjectCSS string `json:"injectCSS,omitempty"`
+	// InjectCSSURL is the URL of a stylesheet applied after page load, before capture.
+	InjectCSSURL string `json:"injectCSSURL,omitempty"`
+	// Actions are browser interactions performed before capture.
+	Actions []Action `json:"actions,omitempty"`
+	// Login performs a scripted login before navigating to the target URL.
+	Login *LoginConfig `json:"login,omitempty"`
+	// BasicAuth authenticates to the target site with HTTP Basic Auth.
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
 	// Delay is the time to wait after page load before capture (in milliseconds).
 	Delay int `json:"delay,omitempty"`
 	// WaitUntil specifies the page load event to wait for.
 	WaitUntil WaitUntil `json:"waitUntil,omitempty"`
 	// WaitForSelector waits for a specific CSS selector to appear.
 	WaitForSelector string `json:"waitForSelector,omitempty"`
+	// WaitForSelectors waits for several CSS selectors, combined by WaitMode.
+	WaitForSelectors []string `json:"waitForSelectors,omitempty"`
+	// WaitMode combines WaitForSelectors (default WaitModeAll).
+	WaitMode WaitMode `json:"waitMode,omitempty"`
 	// WaitForTimeout is an additional wait time in milliseconds.
 	WaitForTimeout int `json:"waitForTimeout,omitempty"`
+	// WaitForFunction waits until the JavaScript expression returns a truthy
+	// value, e.g. "window.__APP_READY === true".
+	WaitForFunction string `json:"waitForFunction,omitempty"`
+	// WaitForFunctionTimeout is the maximum time in milliseconds to wait for
+	// WaitForFunction. The API default applies if zero.
+	WaitForFunctionTimeout int `json:"waitForFunctionTimeout,omitempty"`
+	// WaitForRequest waits until the page sends a request whose URL matches
+	// the pattern (glob, or regular expression enclosed in slashes).
+	WaitForRequest string `json:"waitForRequest,omitempty"`
+	// WaitForResponse waits until a response whose URL matches the pattern
+	// has been received, e.g. "*/api/dashboard-data*".
+	WaitForResponse string `json:"waitForResponse,omitempty"`
+	// ScrollThrough scrolls to the bottom of the page and back before capture
+	// to trigger lazy-loaded content.
+	ScrollThrough bool `json:"scrollThrough,omitempty"`
+	// ScrollStep is the scroll distance per step in pixels when ScrollThrough is set.
+	ScrollStep int `json:"scrollStep,omitempty"`
+	// ScrollPause is the pause after each scroll step in milliseconds when ScrollThrough is set.
+	ScrollPause int `json:"scrollPause,omitempty"`
 	// Cookies are cookies to set before navigation.
 	Cookies []Cookie `json:"cookies,omitempty"`
 	// Headers are custom HTTP headers to send.
 	Headers []Header `json:"headers,omitempty"`
 	// UserAgent sets a custom user agent string.
 	UserAgent string `json:"userAgent,omitempty"`
+	// Locale sets the browser locale (e.g., "de-DE").
+	Locale string `json:"locale,omitempty"`
+	// Timezone sets the browser timezone as an IANA name (e.g., "Europe/Berlin").
+	Timezone string `json:"timezone,omitempty"`
 	// DeviceScaleFactor sets the device scale factor (DPR).
 	DeviceScaleFactor float64 `json:"deviceScaleFactor,omitempty"`
 	// IsMobile emulates a mobile device.
@@ -166,14 +454,41 @@ type ScreenshotOptions struct {
 	IsLandscape bool `json:"isLandscape,omitempty"`
 	// DarkMode enables dark mode emulation.
 	DarkMode bool `json:"darkMode,omitempty"`
+	// EmulateMedia emulates accessibility-related CSS media features.
+	EmulateMedia *EmulateMedia `json:"emulateMedia,omitempty"`
 	// BlockAds blocks advertisements.
 	BlockAds bool `json:"blockAds,omitempty"`
 	// BlockTrackers blocks tracking scripts.
 	BlockTrackers bool `json:"blockTrackers,omitempty"`
+	// BlockURLPatterns blocks requests whose URL matches any of the patterns.
+	// Patterns are globs (e.g., "*://*.hotjar.com/*"), or regular expressions
+	// when enclosed in slashes (e.g., "/analytics\.js$/").
+	BlockURLPatterns []string `json:"blockURLPatterns,omitempty"`
 	// BypassCSP bypasses Content Security Policy.
 	BypassCSP bool `json:"bypassCSP,omitempty"`
 	// JavaScript enables or disables JavaScript (enabled by default).
 	JavaScript *bool `json:"javascript,omitempty"`
+	// AssertTextPresent lists texts that must appear on the rendered page.
+	AssertTextPresent []string `json:"assertTextPresent,omitempty"`
+	// AssertTextAbsent lists texts that must not appear on the rendered page.
+	AssertTextAbsent []string `json:"assertTextAbsent,omitempty"`
+	// Geolocation emulates the device location.
+	Geolocation *Geolocation `json:"geolocation,omitempty"`
+	// Proxy routes the capture through a proxy, e.g. for geo-specific renders.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+	// OCR recognizes text in the capture and returns it in ScreenshotResult.TextBlocks.
+	OCR bool `json:"ocr,omitempty"`
+	// Watermark stamps a text or image overlay on the output.
+	Watermark *WatermarkOptions `json:"watermark,omitempty"`
+	// Storage uploads the output to a bucket; the result then holds the
+	// object's location instead of the data.
+	Storage *StorageConfig `json:"storage,omitempty"`
+	// Priority is the job's queue priority at the API, e.g. PriorityLow for
+	// overnight batches so interactive captures from the same key go first.
+	Priority Priority `json:"priority,omitempty"`
+	// ClientReference is an opaque value stored with the job and echoed in
+	// webhook payloads, e.g. to route results to an order.
+	ClientReference string `json:"clientReference,omitempty"`
 	// Webhook configures async webhook delivery.
 	Webhook *WebhookConfig `json:"webhook,omitempty"`
 }
@@ -182,6 +497,9 @@ type ScreenshotOptions struct {
 type PDFOptions struct {
 	// URL is the target URL to convert to PDF.
 	URL string `json:"url"`
+	// URLs renders several pages into one PDF, each starting a new section,
+	// in place of URL. PDFResult.Sections reports the outcome per URL.
+	URLs []string `json:"urls,omitempty"`
 	// Format is the paper format (A4, Letter, etc.).
 	Format PDFFormat `json:"format,omitempty"`
 	// Orientation is the page orientation (portrait or landscape).
@@ -204,36 +522,106 @@ type PDFOptions struct {
 	PreferCSSPageSize bool `json:"preferCSSPageSize,omitempty"`
 	// PageRanges specifies which pages to include (e.g., "1-5, 8, 11-13").
 	PageRanges string `json:"pageRanges,omitempty"`
+	// GenerateOutline builds PDF bookmarks from the page's h1-h3 headings.
+	GenerateOutline bool `json:"generateOutline,omitempty"`
+	// TOCTemplate is the HTML template of a table of contents page rendered
+	// from the outline and prepended to the document. Requires GenerateOutline.
+	TOCTemplate string `json:"tocTemplate,omitempty"`
 	// Margin sets the page margins.
 	Margin *PDFMargin `json:"margin,omitempty"`
 	// Viewport sets the browser viewport dimensions.
 	Viewport *Viewport `json:"viewport,omitempty"`
 	// AcceptCookies automatically accepts cookie consent banners.
 	AcceptCookies bool `json:"acceptCookies,omitempty"`
+	// HideSelectors lists CSS selectors of elements to hide before capture
+	// (e.g., chat widgets or sticky headers).
+	HideSelectors []string `json:"hideSelectors,omitempty"`
+	// InjectCSS is a stylesheet applied after page load, before capture.
+	InjectCSS string `json:"injectCSS,omitempty"`
+	// InjectCSSURL is the URL of a stylesheet applied after page load, before capture.
+	InjectCSSURL string `json:"injectCSSURL,omitempty"`
+	// Actions are browser interactions performed before capture.
+	Actions []Action `json:"actions,omitempty"`
+	// Login performs a scripted login before navigating to the target URL.
+	Login *LoginConfig `json:"login,omitempty"`
+	// BasicAuth authenticates to the target site with HTTP Basic Auth.
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
 	// Delay is the time to wait after page load before PDF generation (in milliseconds).
 	Delay int `json:"delay,omitempty"`
 	// WaitUntil specifies the page load event to wait for.
 	WaitUntil WaitUntil `json:"waitUntil,omitempty"`
 	// WaitForSelector waits for a specific CSS selector to appear.
 	WaitForSelector string `json:"waitForSelector,omitempty"`
+	// WaitForSelectors waits for several CSS selectors, combined by WaitMode.
+	WaitForSelectors []string `json:"waitForSelectors,omitempty"`
+	// WaitMode combines WaitForSelectors (default WaitModeAll).
+	WaitMode WaitMode `json:"waitMode,omitempty"`
 	// WaitForTimeout is an additional wait time in milliseconds.
 	WaitForTimeout int `json:"waitForTimeout,omitempty"`
+	// WaitForFunction waits until the JavaScript expression returns a truthy
+	// value, e.g. "window.__APP_READY === true".
+	WaitForFunction string `json:"waitForFunction,omitempty"`
+	// WaitForFunctionTimeout is the maximum time in milliseconds to wait for
+	// WaitForFunction. The API default applies if zero.
+	WaitForFunctionTimeout int `json:"waitForFunctionTimeout,omitempty"`
+	// WaitForRequest waits until the page sends a request whose URL matches
+	// the pattern (glob, or regular expression enclosed in slashes).
+	WaitForRequest string `json:"waitForRequest,omitempty"`
+	// WaitForResponse waits until a response whose URL matches the pattern
+	// has been received, e.g. "*/api/dashboard-data*".
+	WaitForResponse string `json:"waitForResponse,omitempty"`
+	// ScrollThrough scrolls to the bottom of the page and back before capture
+	// to trigger lazy-loaded content.
+	ScrollThrough bool `json:"scrollThrough,omitempty"`
+	// ScrollStep is the scroll distance per step in pixels when ScrollThrough is set.
+	ScrollStep int `json:"scrollStep,omitempty"`
+	// ScrollPause is the pause after each scroll step in milliseconds when ScrollThrough is set.
+	ScrollPause int `json:"scrollPause,omitempty"`
 	// Cookies are cookies to set before navigation.
 	Cookies []Cookie `json:"cookies,omitempty"`
 	// Headers are custom HTTP headers to send.
 	Headers []Header `json:"headers,omitempty"`
 	// UserAgent sets a custom user agent string.
 	UserAgent string `json:"userAgent,omitempty"`
+	// Locale sets the browser locale (e.g., "de-DE").
+	Locale string `json:"locale,omitempty"`
+	// Timezone sets the browser timezone as an IANA name (e.g., "Europe/Berlin").
+	Timezone string `json:"timezone,omitempty"`
 	// DarkMode enables dark mode emulation.
 	DarkMode bool `json:"darkMode,omitempty"`
+	// EmulateMedia emulates accessibility-related CSS media features.
+	EmulateMedia *EmulateMedia `json:"emulateMedia,omitempty"`
 	// BlockAds blocks advertisements.
 	BlockAds bool `json:"blockAds,omitempty"`
 	// BlockTrackers blocks tracking scripts.
 	BlockTrackers bool `json:"blockTrackers,omitempty"`
+	// BlockURLPatterns blocks requests whose URL matches any of the patterns.
+	// Patterns are globs (e.g., "*://*.hotjar.com/*"), or regular expressions
+	// when enclosed in slashes (e.g., "/analytics\.js$/").
+	BlockURLPatterns []string `json:"blockURLPatterns,omitempty"`
 	// BypassCSP bypasses Content Security Policy.
 	BypassCSP bool `json:"bypassCSP,omitempty"`
 	// JavaScript enables or disables JavaScript (enabled by default).
 	JavaScript *bool `json:"javascript,omitempty"`
+	// AssertTextPresent lists texts that must appear on the rendered page.
+	AssertTextPresent []string `json:"assertTextPresent,omitempty"`
+	// AssertTextAbsent lists texts that must not appear on the rendered page.
+	AssertTextAbsent []string `json:"assertTextAbsent,omitempty"`
+	// Geolocation emulates the device location.
+	Geolocation *Geolocation `json:"geolocation,omitempty"`
+	// Proxy routes the capture through a proxy, e.g. for geo-specific renders.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+	// Watermark stamps a text or image overlay on the output.
+	Watermark *WatermarkOptions `json:"watermark,omitempty"`
+	// Storage uploads the output to a bucket; the result then holds the
+	// object's location instead of the data.
+	Storage *StorageConfig `json:"storage,omitempty"`
+	// Priority is the job's queue priority at the API, e.g. PriorityLow for
+	// overnight batches so interactive captures from the same key go first.
+	Priority Priority `json:"priority,omitempty"`
+	// ClientReference is an opaque value stored with the job and echoed in
+	// webhook payloads, e.g. to route results to an order.
+	ClientReference string `json:"clientReference,omitempty"`
 	// Webhook configures async webhook delivery.
 	Webhook *WebhookConfig `json:"webhook,omitempty"`
 }
@@ -250,8 +638,36 @@ type PDFMargin struct {
 	Left string `json:"left,omitempty"`
 }
 
+// RetryInfo describes the HTTP attempts made for a request.
+type RetryInfo struct {
+	// Attempts is the number of HTTP attempts made, including the first.
+	Attempts int
+	// RetryWaits contains the backoff waited before each retry.
+	RetryWaits []time.Duration
+}
+
+// Retried reports whether the request needed more than one attempt.
+func (r RetryInfo) Retried() bool {
+	return r.Attempts > 1
+}
+
+// ResponseInfo describes the HTTP response a result was read from.
+type ResponseInfo struct {
+	// RequestID is the unique request ID, for correlation with support tickets.
+	RequestID string
+	// Duration is the time from sending the request to reading the response,
+	// including any retries.
+	Duration time.Duration
+	// Headers are the response headers.
+	Headers http.Header
+}
+
 // ScreenshotResult represents the result of a screenshot operation.
 type ScreenshotResult struct {
+	RetryInfo
+	CacheInfo
+	ResponseInfo
+
 	// Data contains the screenshot image data.
 	Data []byte
 	// ContentType is the MIME type of the image.
@@ -264,10 +680,97 @@ type ScreenshotResult struct {
 	Height int
 	// JobID is the async job ID when using webhooks.
 	JobID string
+	// CapturedAt is when the capture was received from the API.
+	CapturedAt time.Time
+	// OptionsHash is the fingerprint of the capture options (see OptionsFingerprint).
+	OptionsHash string
+	// Thumbnail contains the thumbnail image when one was requested.
+	Thumbnail []byte
+	// Images contains the image data per format when Formats was requested.
+	// Data holds the image in Format, or in the first of Formats.
+	Images map[Format][]byte
+	// TextBlocks contains the recognized text regions when OCR is enabled.
+	TextBlocks []TextBlock
+	// Assertions contains the results of text assertions, if any were requested.
+	Assertions Assertions
+	// Storage is the uploaded object when Storage was requested.
+	Storage *StoredObject
+}
+
+// TextBlock represents a region of text recognized by OCR.
+type TextBlock struct {
+	// Text is the recognized text.
+	Text string `json:"text"`
+	// Confidence is the recognition confidence (0-1).
+	Confidence float64 `json:"confidence"`
+	// BoundingBox is the region of the image containing the text.
+	BoundingBox BoundingBox `json:"boundingBox"`
+}
+
+// AssertionType represents the kind of a text assertion.
+type AssertionType string
+
+const (
+	// AssertPresent asserts that a text appears on the page.
+	AssertPresent AssertionType = "present"
+	// AssertAbsent asserts that a text does not appear on the page.
+	AssertAbsent AssertionType = "absent"
+)
+
+// AssertionResult represents the outcome of a single text assertion.
+type AssertionResult struct {
+	// Type is the kind of assertion.
+	Type AssertionType `json:"type"`
+	// Text is the asserted text.
+	Text string `json:"text"`
+	// Passed is true if the assertion held.
+	Passed bool `json:"passed"`
+	// Count is the number of occurrences found on the page.
+	Count int `json:"count"`
+}
+
+// Assertions is a list of text assertion results.
+type Assertions []AssertionResult
+
+// Passed reports whether all assertions held.
+func (a Assertions) Passed() bool {
+	for _, r := range a {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the assertions that did not hold.
+func (a Assertions) Failed() Assertions {
+	var failed Assertions
+	for _, r := range a {
+		if !r.Passed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// BoundingBox represents a rectangular region of an image in pixels.
+type BoundingBox struct {
+	// X is the horizontal offset from the left edge.
+	X int `json:"x"`
+	// Y is the vertical offset from the top edge.
+	Y int `json:"y"`
+	// Width is the width of the region.
+	Width int `json:"width"`
+	// Height is the height of the region.
+	Height int `json:"height"`
 }
 
 // PDFResult represents the result of a PDF generation operation.
 type PDFResult struct {
+	RetryInfo
+	CacheInfo
+	ResponseInfo
+
 	// Data contains the PDF data.
 	Data []byte
 	// ContentType is the MIME type (application/pdf).
@@ -276,8 +779,35 @@ type PDFResult struct {
 	URL string
 	// Pages is the number of pages in the PDF.
 	Pages int
+	// Sections describes each URL of a multi-URL PDF, in URLs order.
+	Sections []PDFSection
 	// JobID is the async job ID when using webhooks.
 	JobID string
+	// CapturedAt is when the capture was received from the API.
+	CapturedAt time.Time
+	// OptionsHash is the fingerprint of the capture options (see OptionsFingerprint).
+	OptionsHash string
+	// Assertions contains the results of text assertions, if any were requested.
+	Assertions Assertions
+	// Storage is the uploaded object when Storage was requested.
+	Storage *StoredObject
+}
+
+// PDFSection describes the part of a multi-URL PDF rendered from one URL.
+type PDFSection struct {
+	// URL is the rendered URL.
+	URL string `json:"url"`
+	// StartPage is the 1-based page the section starts on (0 if it failed).
+	StartPage int `json:"startPage,omitempty"`
+	// Pages is the number of pages in the section.
+	Pages int `json:"pages,omitempty"`
+	// Error describes why the URL could not be rendered, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Failed reports whether the section could not be rendered.
+func (s PDFSection) Failed() bool {
+	return s.Error != ""
 }
 
 // APIResponse represents a generic API response.
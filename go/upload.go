@@ -0,0 +1,182 @@
+package screencraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrNilUploader is returned by ScreenshotAndUpload when no uploader is given.
+var ErrNilUploader = errors.New("screencraft: uploader is required")
+
+// Uploader stores capture results in a storage backend such as S3, GCS or a
+// local directory.
+type Uploader interface {
+	// Upload stores the contents of r under key and returns the URL of the
+	// stored object. r must be read to completion before Upload returns.
+	Upload(ctx context.Context, key string, r io.Reader, contentType string) (url string, err error)
+}
+
+// UploaderFunc adapts a function to the Uploader interface.
+type UploaderFunc func(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+
+// Upload calls f(ctx, key, r, contentType).
+func (f UploaderFunc) Upload(ctx context.Context, key string, r io.Reader, contentType string) (string, error) {
+	return f(ctx, key, r, contentType)
+}
+
+// UploadResult represents the result of ScreenshotAndUpload.
+type UploadResult struct {
+	RetryInfo
+	ResponseInfo
+
+	// Key is the key the screenshot was uploaded under (see UploadKey).
+	Key string
+	// URL is the URL returned by the uploader.
+	URL string
+	// ContentType is the MIME type of the screenshot.
+	ContentType string
+	// Size is the number of bytes uploaded.
+	Size int64
+	// CapturedAt is when the capture was received from the API.
+	CapturedAt time.Time
+	// OptionsHash is the fingerprint of the capture options (see OptionsFingerprint).
+	OptionsHash string
+}
+
+// UploadKey returns the key ScreenshotAndUpload uploads a screenshot under:
+// the options fingerprint with the extension of the primary format, e.g.
+// "3f2a...9c.png". Identical captures therefore overwrite each other instead
+// of piling up.
+func UploadKey(opts *ScreenshotOptions) string {
+	return OptionsFingerprint(opts) + "." + string(primaryFormat(opts))
+}
+
+// ScreenshotAndUpload captures a screenshot and pushes it to uploader in one
+// call. The image is streamed from the API response into the uploader
+// without being buffered, unless it has to be resized or cached by the
+// client, or the API returns it alongside additional data.
+//
+// To have the API upload to S3 itself, use ScreenshotOptions.Storage instead.
+//
+// Example:
+//
+//	result, err := client.ScreenshotAndUpload(ctx, &screencraft.ScreenshotOptions{
+//	    URL: "https://example.com",
+//	}, bucketUploader)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result.URL)
+func (c *Client) ScreenshotAndUpload(ctx context.Context, opts *ScreenshotOptions, uploader Uploader, callOpts ...CallOption) (*UploadResult, error) {
+	ctx = withCallOptions(ctx, callOpts)
+
+	if uploader == nil {
+		return nil, ErrNilUploader
+	}
+
+	if err := ValidateScreenshotOptions(opts); err != nil {
+		return nil, err
+	}
+
+	if opts.Webhook != nil {
+		return nil, NewValidationError("webhook", "cannot be combined with an uploader", "exclusive").Error
+	}
+
+	if opts.Storage != nil {
+		return nil, NewValidationError("storage", "cannot be combined with an uploader", "exclusive").Error
+	}
+
+	key := UploadKey(opts)
+
+	// Resized and cacheable captures are buffered by Screenshot anyway
+	if opts.Resize != nil || c.cacheKey("screenshot", opts) != "" {
+		result, err := c.Screenshot(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return c.uploadScreenshot(ctx, uploader, key, result)
+	}
+
+	reqBody := c.buildScreenshotRequest(opts)
+
+	start := time.Now()
+	resp, info, err := c.doRequest(ctx, http.MethodPost, screenshotEndpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		result, err := c.parseScreenshotResponse(resp, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.RetryInfo = info
+		result.ResponseInfo = newResponseInfo(resp, start)
+		result.CapturedAt = time.Now().UTC()
+		result.OptionsHash = OptionsFingerprint(opts)
+		return c.uploadScreenshot(ctx, uploader, key, result)
+	}
+
+	body := &countingReader{r: resp.Body}
+	url, err := uploader.Upload(ctx, key, body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to upload %s: %w", key, err)
+	}
+
+	return &UploadResult{
+		RetryInfo:    info,
+		ResponseInfo: newResponseInfo(resp, start),
+		Key:          key,
+		URL:          url,
+		ContentType:  contentType,
+		Size:         body.n,
+		CapturedAt:   time.Now().UTC(),
+		OptionsHash:  OptionsFingerprint(opts),
+	}, nil
+}
+
+// uploadScreenshot uploads the data of a buffered screenshot result.
+func (c *Client) uploadScreenshot(ctx context.Context, uploader Uploader, key string, result *ScreenshotResult) (*UploadResult, error) {
+	if len(result.Data) == 0 {
+		return nil, errors.New("screencraft: screenshot returned no image data")
+	}
+
+	url, err := uploader.Upload(ctx, key, bytes.NewReader(result.Data), result.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to upload %s: %w", key, err)
+	}
+
+	return &UploadResult{
+		RetryInfo:    result.RetryInfo,
+		ResponseInfo: result.ResponseInfo,
+		Key:          key,
+		URL:          url,
+		ContentType:  result.ContentType,
+		Size:         int64(len(result.Data)),
+		CapturedAt:   result.CapturedAt,
+		OptionsHash:  result.OptionsHash,
+	}, nil
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,224 @@
+package screencraft
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// NormalizeTargetURL normalizes a user-supplied capture URL.
+//
+// It defaults the scheme to https, lowercases the scheme and host, converts
+// internationalized domain names to punycode, removes default ports and the
+// fragment, and ensures a non-empty path. URLs with embedded credentials or
+// a scheme other than http or https are rejected.
+//
+// The client uses the normalized form to validate options and compute
+// fingerprints, but sends target URLs to the API as given, so fragments used
+// by hash-routed pages are kept.
+//
+// Example:
+//
+//	u, err := screencraft.NormalizeTargetURL("Bücher.example/Shop#top")
+//	// u == "https://xn--bcher-kva.example/Shop"
+func NormalizeTargetURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ErrMissingURL
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + strings.TrimPrefix(raw, "//")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURL, u.Scheme)
+	}
+
+	if u.User != nil {
+		return "", fmt.Errorf("%w: credentials in URL are not allowed", ErrInvalidURL)
+	}
+
+	hostname := u.Hostname()
+	if hostname == "" {
+		return "", fmt.Errorf("%w: missing host", ErrInvalidURL)
+	}
+
+	host, err := toASCIIHost(strings.ToLower(hostname))
+	if err != nil {
+		return "", err
+	}
+
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	} else {
+		u.Host = host
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	return u.String(), nil
+}
+
+// normalizedURL returns the normalized form of raw, or raw itself if it
+// cannot be normalized.
+func normalizedURL(raw string) string {
+	if u, err := NormalizeTargetURL(raw); err == nil {
+		return u
+	}
+	return raw
+}
+
+// toASCIIHost converts each non-ASCII label of a host name to punycode.
+func toASCIIHost(host string) (string, error) {
+	if isASCII(host) {
+		return host, nil
+	}
+
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		if !utf8.ValidString(label) {
+			return "", fmt.Errorf("%w: invalid host name", ErrInvalidURL)
+		}
+		labels[i] = "xn--" + punycodeEncode(label)
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Punycode parameters (RFC 3492).
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// punycodeEncode encodes a Unicode label as punycode (RFC 3492), without the
+// "xn--" prefix.
+func punycodeEncode(label string) string {
+	runes := []rune(label)
+
+	var out strings.Builder
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+		}
+	}
+
+	basic := out.Len()
+	handled := basic
+	if basic > 0 {
+		out.WriteByte('-')
+	}
+
+	n := rune(punyInitialN)
+	delta := 0
+	bias := punyInitialBias
+
+	for handled < len(runes) {
+		// Find the smallest code point not yet handled
+		m := rune(0x10FFFF)
+		for _, r := range runes {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+
+		delta += int(m-n) * (handled + 1)
+		n = m
+
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := k - bias
+				if t < punyTMin {
+					t = punyTMin
+				} else if t > punyTMax {
+					t = punyTMax
+				}
+				if q < t {
+					break
+				}
+				out.WriteByte(punyDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punyDigit(q))
+
+			bias = punyAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+
+		delta++
+		n++
+	}
+
+	return out.String()
+}
+
+// punyDigit returns the punycode character for a digit value.
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// punyAdapt is the punycode bias adaptation function.
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
@@ -0,0 +1,110 @@
+package screencraft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	usageEndpoint = "/usage"
+)
+
+// Usage represents the account's consumption in the current billing period.
+type Usage struct {
+	RetryInfo `json:"-"`
+
+	// PeriodStart is the start of the current billing period.
+	PeriodStart time.Time `json:"periodStart"`
+	// PeriodEnd is when the current billing period ends and credits reset.
+	PeriodEnd time.Time `json:"periodEnd"`
+	// Requests is the number of requests made in the current period.
+	Requests UsageRequests `json:"requests"`
+	// CreditsUsed is the number of credits consumed in the current period.
+	CreditsUsed int `json:"creditsUsed"`
+	// CreditsRemaining is the number of credits left in the current period.
+	CreditsRemaining int `json:"creditsRemaining"`
+	// Limits are the limits of the account's plan.
+	Limits PlanLimits `json:"limits"`
+}
+
+// UsageRequests breaks down request counts by operation.
+type UsageRequests struct {
+	// Total is the number of requests of all kinds.
+	Total int `json:"total"`
+	// Screenshots is the number of screenshot requests.
+	Screenshots int `json:"screenshots"`
+	// PDFs is the number of PDF requests.
+	PDFs int `json:"pdfs"`
+	// Failed is the number of requests that failed.
+	Failed int `json:"failed"`
+}
+
+// PlanLimits represents the limits of a plan.
+type PlanLimits struct {
+	// Credits is the number of credits included per billing period.
+	Credits int `json:"credits"`
+	// RequestsPerMinute is the request rate limit.
+	RequestsPerMinute int `json:"requestsPerMinute"`
+	// Concurrency is the maximum number of requests processed in parallel.
+	Concurrency int `json:"concurrency"`
+}
+
+// CreditsUsedRatio returns the fraction of the period's credits consumed, in
+// the range [0, 1]. It returns 0 if the plan has no credit limit.
+func (u *Usage) CreditsUsedRatio() float64 {
+	total := u.CreditsUsed + u.CreditsRemaining
+	if total <= 0 {
+		return 0
+	}
+	return float64(u.CreditsUsed) / float64(total)
+}
+
+// usageResponse is the API response for a usage request.
+type usageResponse struct {
+	APIResponse
+	Data *Usage `json:"data,omitempty"`
+}
+
+// Usage returns request counts, credit consumption and plan limits for the
+// current billing period.
+//
+// Example:
+//
+//	usage, err := client.Usage(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if usage.CreditsUsedRatio() > 0.9 {
+//	    log.Printf("%d credits left until %s", usage.CreditsRemaining, usage.PeriodEnd)
+//	}
+func (c *Client) Usage(ctx context.Context) (*Usage, error) {
+	resp, info, err := c.doRequest(ctx, http.MethodGet, usageEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("screencraft: failed to read response: %w", err)
+	}
+
+	var usageResp usageResponse
+	if err := c.decodeJSON(body, &usageResp); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse response: %w", err)
+	}
+
+	if !usageResp.Success || usageResp.Data == nil {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    usageResp.Message,
+		}
+	}
+
+	usage := usageResp.Data
+	usage.RetryInfo = info
+	return usage, nil
+}
@@ -0,0 +1,74 @@
+package screencraft
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// apiVersionHeader carries the API version in requests and responses.
+const apiVersionHeader = "X-API-Version"
+
+// versionSegment matches a trailing version path segment such as "/v1".
+var versionSegment = regexp.MustCompile(`/v[0-9]+$`)
+
+// WithAPIVersion pins the client to an API version such as "v1" or "v2".
+//
+// The version replaces the version segment at the end of the base URL, if
+// any, and is sent in the X-API-Version header of every request, so the
+// client's behavior does not change when DefaultBaseURL does.
+//
+// Example:
+//
+//	client := screencraft.New(apiKey, screencraft.WithAPIVersion("v2"))
+func WithAPIVersion(v string) Option {
+	return func(c *Client) {
+		v = strings.TrimSpace(v)
+		if v != "" && !strings.HasPrefix(v, "v") {
+			v = "v" + v
+		}
+		c.apiVersion = v
+	}
+}
+
+// APIVersion returns the API version the client is pinned to, or an empty
+// string if it uses whatever version its base URL points to.
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// ServerAPIVersion returns the API version reported by the server in the
+// last response, or an empty string if none was reported yet.
+func (c *Client) ServerAPIVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverAPIVersion
+}
+
+// versionedBaseURL returns base with the pinned API version applied.
+func (c *Client) versionedBaseURL(base string) string {
+	if c.apiVersion == "" {
+		return base
+	}
+	base = strings.TrimRight(base, "/")
+	return versionSegment.ReplaceAllString(base, "/"+c.apiVersion)
+}
+
+// setVersionHeader sets the X-API-Version header if a version is pinned.
+func (c *Client) setVersionHeader(req *http.Request) {
+	if c.apiVersion != "" {
+		req.Header.Set(apiVersionHeader, c.apiVersion)
+	}
+}
+
+// recordServerVersion remembers the API version reported in resp.
+func (c *Client) recordServerVersion(resp *http.Response) {
+	v := resp.Header.Get(apiVersionHeader)
+	if v == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.serverAPIVersion = v
+}
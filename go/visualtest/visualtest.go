@@ -0,0 +1,195 @@
+// Package visualtest turns screenshots into visual regression tests.
+//
+// AssertMatchesGolden compares a capture with a golden image using a
+// perceptual color difference, so anti-aliasing and compression noise do not
+// fail the test while real layout changes do. When a capture differs, the
+// actual image and a diff image highlighting the changed pixels in red are
+// written next to the golden file (or to $VISUALTEST_ARTIFACTS) so CI can
+// upload them.
+//
+// Basic usage:
+//
+//	func TestHomePage(t *testing.T) {
+//	    result, err := client.Screenshot(ctx, &screencraft.ScreenshotOptions{
+//	        URL: "https://staging.example.com",
+//	    })
+//	    if err != nil {
+//	        t.Fatal(err)
+//	    }
+//	    visualtest.AssertMatchesGolden(t, result, "testdata/home.png", 0.001)
+//	}
+//
+// Run the tests with -visualtest.update, or with VISUALTEST_UPDATE=1, to
+// create or update golden files.
+package visualtest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+	"github.com/DancingTedDanson011/screencraft-go/internal/imageutil"
+)
+
+// DefaultPixelThreshold is the default perceptual color difference (0-1)
+// above which two pixels count as different.
+const DefaultPixelThreshold = 0.1
+
+var update = flag.Bool("visualtest.update", false, "update visualtest golden files")
+
+// Options configures a comparison.
+type Options struct {
+	// PixelThreshold is the perceptual color difference (0-1) above which
+	// two pixels count as different. Defaults to DefaultPixelThreshold.
+	PixelThreshold float64
+
+	// DiffImage generates a highlight image if the images differ.
+	DiffImage bool
+}
+
+// Result is the result of comparing two images.
+type Result struct {
+	// DiffPixels is the number of differing pixels.
+	DiffPixels int
+
+	// DiffRatio is the fraction of differing pixels (0-1).
+	DiffRatio float64
+
+	// SizeMismatch is true if the images have different dimensions.
+	SizeMismatch bool
+
+	// Diff highlights differing pixels in red, if Options.DiffImage is set.
+	Diff image.Image
+}
+
+// Compare compares two images pixel by pixel using a perceptual (YIQ) color
+// difference.
+func Compare(want, got image.Image, opts *Options) *Result {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PixelThreshold <= 0 {
+		o.PixelThreshold = DefaultPixelThreshold
+	}
+
+	d := imageutil.Compare(want, got, o.PixelThreshold, o.DiffImage)
+	r := &Result{
+		DiffPixels:   d.Pixels,
+		DiffRatio:    d.Ratio,
+		SizeMismatch: d.SizeMismatch,
+	}
+	if d.Image != nil {
+		r.Diff = d.Image
+	}
+	return r
+}
+
+// AssertMatchesGolden fails t unless the screenshot in result matches the
+// golden PNG at path, allowing a fraction threshold (0-1) of pixels to
+// differ. On failure, the actual and diff images are written as artifacts.
+//
+// With -visualtest.update or VISUALTEST_UPDATE=1, the golden file is
+// (re)written from result instead.
+func AssertMatchesGolden(t testing.TB, result *screencraft.ScreenshotResult, path string, threshold float64) {
+	t.Helper()
+
+	if result == nil || len(result.Data) == 0 {
+		t.Fatalf("visualtest: screenshot data is required")
+		return
+	}
+	got, err := imageutil.Decode(result.Data)
+	if err != nil {
+		if errors.Is(err, imageutil.ErrUnsupportedFormat) {
+			t.Fatalf("visualtest: cannot decode %s screenshots; capture PNG or JPEG", result.ContentType)
+			return
+		}
+		t.Fatalf("visualtest: %v", err)
+		return
+	}
+
+	if updating() {
+		if err := writePNG(path, got); err != nil {
+			t.Fatalf("visualtest: failed to update golden file: %v", err)
+			return
+		}
+		t.Logf("visualtest: updated golden file %s", path)
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("visualtest: golden file %s does not exist; run with -visualtest.update to create it", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("visualtest: failed to read golden file: %v", err)
+		return
+	}
+	want, err := imageutil.Decode(data)
+	if err != nil {
+		t.Fatalf("visualtest: golden file %s: %v", path, err)
+		return
+	}
+
+	r := Compare(want, got, &Options{DiffImage: true})
+	if !r.SizeMismatch && r.DiffRatio <= threshold {
+		return
+	}
+
+	actualPath, diffPath := artifactPaths(path)
+	if err := writePNG(actualPath, got); err != nil {
+		t.Logf("visualtest: failed to write actual image: %v", err)
+	}
+	if r.Diff != nil {
+		if err := writePNG(diffPath, r.Diff); err != nil {
+			t.Logf("visualtest: failed to write diff image: %v", err)
+		}
+	}
+
+	if r.SizeMismatch {
+		t.Errorf("visualtest: %s: size %dx%d, golden is %dx%d (actual: %s)",
+			path, got.Bounds().Dx(), got.Bounds().Dy(), want.Bounds().Dx(), want.Bounds().Dy(), actualPath)
+		return
+	}
+	t.Errorf("visualtest: %s: %.3f%% of pixels differ, threshold is %.3f%% (actual: %s, diff: %s)",
+		path, r.DiffRatio*100, threshold*100, actualPath, diffPath)
+}
+
+// updating reports whether golden files should be rewritten.
+func updating() bool {
+	return *update || os.Getenv("VISUALTEST_UPDATE") == "1"
+}
+
+// artifactPaths returns where the actual and diff images for the golden
+// file at path are written.
+func artifactPaths(path string) (string, string) {
+	dir, name := filepath.Split(path)
+	if artifacts := os.Getenv("VISUALTEST_ARTIFACTS"); artifacts != "" {
+		dir = artifacts
+	}
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	return filepath.Join(dir, base+".actual.png"), filepath.Join(dir, base+".diff.png")
+}
+
+// writePNG encodes img as PNG to path, creating directories as needed.
+func writePNG(path string, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
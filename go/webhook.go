@@ -0,0 +1,316 @@
+package screencraft
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader is the header carrying the webhook signature.
+	WebhookSignatureHeader = "X-ScreenCraft-Signature"
+
+	// maxWebhookPayload is the maximum accepted webhook payload size.
+	maxWebhookPayload = 10 << 20
+)
+
+// ErrInvalidSignature is returned when a webhook signature does not match.
+var ErrInvalidSignature = errors.New("screencraft: invalid webhook signature")
+
+// permanentError marks a webhook processing error as not retryable.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps a webhook processing error to mark it as not retryable.
+// The WebhookHandler acknowledges such events instead of asking the API to
+// redeliver them, and passes them to the dead-letter hook.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permErr *permanentError
+	return errors.As(err, &permErr)
+}
+
+// WebhookEvent represents a webhook delivered by the API when an async
+// operation completes.
+type WebhookEvent struct {
+	// ID uniquely identifies the event. Redeliveries share the same ID.
+	ID string `json:"id"`
+	// Type is the event type (e.g., "screenshot.completed").
+	Type string `json:"type"`
+	// JobID is the async job ID.
+	JobID string `json:"jobId"`
+	// Status is the job status (e.g., "completed", "failed").
+	Status string `json:"status"`
+	// URL is the captured URL.
+	URL string `json:"url"`
+	// ClientReference is the value passed in the capture options, if any.
+	ClientReference string `json:"clientReference,omitempty"`
+	// ResultURL is where the artifact can be downloaded, if the job succeeded.
+	ResultURL string `json:"resultUrl,omitempty"`
+	// Error contains error details if the job failed.
+	Error *APIErrorDetails `json:"error,omitempty"`
+	// CreatedAt is when the event was created.
+	CreatedAt time.Time `json:"createdAt"`
+	// Raw is the original payload.
+	Raw json.RawMessage `json:"-"`
+}
+
+// ParseWebhookEvent parses a webhook payload.
+//
+// If the payload has no event ID, one is derived from its contents so
+// redeliveries of the same payload can still be deduplicated.
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("screencraft: failed to parse webhook payload: %w", err)
+	}
+
+	if event.ID == "" {
+		sum := sha256.Sum256(payload)
+		event.ID = hex.EncodeToString(sum[:])
+	}
+	event.Raw = append(json.RawMessage(nil), payload...)
+
+	return &event, nil
+}
+
+// VerifyWebhookSignature reports whether signature is the hex-encoded
+// HMAC-SHA256 of payload with the webhook secret. An optional "sha256="
+// prefix on the signature is accepted.
+func VerifyWebhookSignature(payload []byte, signature, secret string) bool {
+	signature = strings.TrimPrefix(signature, "sha256=")
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// WebhookInbox persists received webhook events so they survive restarts.
+//
+// The webhook handler saves each event before processing it and marks it done
+// afterwards, which guarantees at-least-once handling. See the webhookinbox
+// package for a file-backed implementation.
+type WebhookInbox interface {
+	// Save persists an event before it is processed. It returns false if the
+	// event has already been handled.
+	Save(event *WebhookEvent) (bool, error)
+
+	// Done marks an event as handled.
+	Done(id string) error
+}
+
+// WebhookHandlerFunc processes a webhook event.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// DeadLetterFunc receives events that failed with a non-retryable error.
+type DeadLetterFunc func(ctx context.Context, event *WebhookEvent, err error) error
+
+// WebhookHandler is an http.Handler receiving API webhooks.
+//
+// It verifies the signature, persists the event in the inbox (if any) and
+// invokes the handler function, retrying it in-process up to Retries times.
+//
+// If processing still fails with a retryable error, the handler responds with
+// 500 so the API redelivers the event. Non-retryable errors are passed to
+// DeadLetter and the event is acknowledged; if DeadLetter itself fails, the
+// handler responds with 500 so the event is not lost.
+type WebhookHandler struct {
+	// Secret is the webhook secret used to verify signatures. Signatures are
+	// not checked if empty.
+	Secret string
+
+	// Inbox optionally persists events before processing.
+	Inbox WebhookInbox
+
+	// Jobs optionally tracks async jobs (see WithJobStore). Processed events
+	// mark their jobs as completed or failed.
+	Jobs JobStore
+
+	// Handle processes each event.
+	Handle WebhookHandlerFunc
+
+	// Retries is the number of in-process retries of Handle before the
+	// event is left for redelivery by the API.
+	Retries int
+
+	// RetryWait is the wait between in-process retries.
+	RetryWait time.Duration
+
+	// IsRetryable classifies processing errors. Defaults to treating all
+	// errors as retryable except those marked with Permanent.
+	IsRetryable func(err error) bool
+
+	// DeadLetter receives events that failed with a non-retryable error.
+	DeadLetter DeadLetterFunc
+}
+
+// NewWebhookHandler creates a WebhookHandler.
+//
+// Example:
+//
+//	http.Handle("/webhook", screencraft.NewWebhookHandler(secret,
+//	    func(ctx context.Context, event *screencraft.WebhookEvent) error {
+//	        log.Printf("job %s: %s", event.JobID, event.Status)
+//	        return nil
+//	    },
+//	))
+func NewWebhookHandler(secret string, handle WebhookHandlerFunc) *WebhookHandler {
+	return &WebhookHandler{
+		Secret: secret,
+		Handle: handle,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayload))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	if h.Secret != "" && !VerifyWebhookSignature(payload, r.Header.Get(WebhookSignatureHeader), h.Secret) {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseWebhookEvent(payload)
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if h.Inbox != nil {
+		isNew, err := h.Inbox.Save(event)
+		if err != nil {
+			http.Error(w, "failed to persist event", http.StatusInternalServerError)
+			return
+		}
+		if !isNew {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if err := h.Deliver(r.Context(), event); err != nil {
+		var deliveryErr *webhookDeliveryError
+		if errors.As(err, &deliveryErr) {
+			http.Error(w, "failed to "+deliveryErr.stage, http.StatusInternalServerError)
+			return
+		}
+		http.Error(w, "failed to process event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Deliver processes an event the way ServeHTTP does once it is verified and
+// saved to the inbox: it runs Handle with retries, passes non-retryable
+// failures to DeadLetter, updates the event's job in Jobs and marks the event
+// done in Inbox. It returns an error if the event was not handled and must be
+// delivered again.
+//
+// Deliver is used to redrive events left in the inbox by a previous run; see
+// the webhookinbox package.
+func (h *WebhookHandler) Deliver(ctx context.Context, event *WebhookEvent) error {
+	if err := h.process(ctx, event); err != nil {
+		if h.retryable(err) {
+			return &webhookDeliveryError{stage: "process event", err: err}
+		}
+
+		if h.DeadLetter != nil {
+			if dlErr := h.DeadLetter(ctx, event, err); dlErr != nil {
+				return &webhookDeliveryError{stage: "dead-letter event", err: dlErr}
+			}
+		}
+	}
+
+	if h.Jobs != nil {
+		if err := finishJob(h.Jobs, event); err != nil {
+			return &webhookDeliveryError{stage: "update job", err: err}
+		}
+	}
+
+	if h.Inbox != nil {
+		if err := h.Inbox.Done(event.ID); err != nil {
+			return &webhookDeliveryError{stage: "persist event", err: err}
+		}
+	}
+	return nil
+}
+
+// webhookDeliveryError reports the stage at which delivering an event failed.
+type webhookDeliveryError struct {
+	stage string
+	err   error
+}
+
+func (e *webhookDeliveryError) Error() string {
+	return fmt.Sprintf("screencraft: failed to %s: %v", e.stage, e.err)
+}
+
+func (e *webhookDeliveryError) Unwrap() error {
+	return e.err
+}
+
+// process runs Handle with in-process retries for retryable errors.
+func (h *WebhookHandler) process(ctx context.Context, event *WebhookEvent) error {
+	if h.Handle == nil {
+		return nil
+	}
+
+	var err error
+	for attempt := 0; attempt <= h.Retries; attempt++ {
+		if attempt > 0 && h.RetryWait > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(h.RetryWait):
+			}
+		}
+
+		err = h.Handle(ctx, event)
+		if err == nil || !h.retryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// retryable reports whether a processing error should be redelivered.
+func (h *WebhookHandler) retryable(err error) bool {
+	if h.IsRetryable != nil {
+		return h.IsRetryable(err)
+	}
+	return !IsPermanent(err)
+}
@@ -0,0 +1,237 @@
+// Package webhookinbox provides a file-backed inbox for ScreenCraft webhooks.
+//
+// Events are written to disk before they are processed and marked done
+// afterwards, so events received before a crash or restart are processed
+// again on startup. This guarantees at-least-once handling without each
+// application writing its own persistence layer.
+//
+// Basic usage:
+//
+//	inbox, err := webhookinbox.Open("/var/lib/myapp/webhooks")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	handler := screencraft.NewWebhookHandler(secret, process)
+//	handler.Inbox = inbox
+//
+//	// Process events left over from a previous run
+//	if err := inbox.Redrive(ctx, handler); err != nil {
+//	    log.Print(err)
+//	}
+//
+//	http.Handle("/webhook", handler)
+package webhookinbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	screencraft "github.com/DancingTedDanson011/screencraft-go"
+)
+
+const (
+	pendingDir = "pending"
+	doneDir    = "done"
+)
+
+// Store is a file-backed webhook inbox. It implements screencraft.WebhookInbox.
+//
+// Pending events are stored as one JSON file each; handled events leave an
+// empty marker file so redeliveries are recognized. A Store is safe for
+// concurrent use within one process.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var _ screencraft.WebhookInbox = (*Store)(nil)
+
+// Open opens or creates an inbox in the given directory.
+func Open(dir string) (*Store, error) {
+	for _, sub := range []string{pendingDir, doneDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("webhookinbox: %w", err)
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Save persists an event before it is processed. It returns false if the
+// event has already been handled.
+func (s *Store) Save(event *screencraft.WebhookEvent) (bool, error) {
+	if event == nil || event.ID == "" {
+		return false, errors.New("webhookinbox: event ID is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := fileName(event.ID)
+	if _, err := os.Stat(filepath.Join(s.dir, doneDir, name)); err == nil {
+		return false, nil
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.dir, pendingDir, name), event.Raw); err != nil {
+		return false, fmt.Errorf("webhookinbox: %w", err)
+	}
+	return true, nil
+}
+
+// Done marks an event as handled.
+func (s *Store) Done(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := fileName(id)
+	if err := writeFileAtomic(filepath.Join(s.dir, doneDir, name), nil); err != nil {
+		return fmt.Errorf("webhookinbox: %w", err)
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, pendingDir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("webhookinbox: %w", err)
+	}
+	return nil
+}
+
+// Pending returns the events that were saved but not marked done, oldest
+// first.
+func (s *Store) Pending() ([]*screencraft.WebhookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, pendingDir))
+	if err != nil {
+		return nil, fmt.Errorf("webhookinbox: %w", err)
+	}
+
+	type pending struct {
+		event   *screencraft.WebhookEvent
+		modTime time.Time
+	}
+
+	var list []pending
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(s.dir, pendingDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("webhookinbox: %w", err)
+		}
+
+		event, err := screencraft.ParseWebhookEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf("webhookinbox: %s: %w", entry.Name(), err)
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("webhookinbox: %w", err)
+		}
+		list = append(list, pending{event: event, modTime: info.ModTime()})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].modTime.Before(list[j].modTime)
+	})
+
+	events := make([]*screencraft.WebhookEvent, len(list))
+	for i, p := range list {
+		events[i] = p.event
+	}
+	return events, nil
+}
+
+// Redrive delivers all pending events with handler, as if they had just been
+// received: failures are retried, dead-lettered and recorded in the job
+// store as configured on handler, and handled events are marked done in s.
+// Events that must be delivered again stay pending and do not stop the
+// others; their errors are returned joined.
+func (s *Store) Redrive(ctx context.Context, handler *screencraft.WebhookHandler) error {
+	events, err := s.Pending()
+	if err != nil {
+		return err
+	}
+
+	// Events are marked done in this store whatever the handler's inbox
+	h := *handler
+	h.Inbox = s
+
+	var errs []error
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := h.Deliver(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("webhookinbox: event %s: %w", event.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Prune removes done markers older than the given age. Redeliveries of pruned
+// events are processed again.
+func (s *Store) Prune(olderThan time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Join(s.dir, doneDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("webhookinbox: %w", err)
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("webhookinbox: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// fileName returns a filesystem-safe file name for an event ID.
+func fileName(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// writeFileAtomic writes data to path via a temporary file and rename, so
+// readers never observe partial files.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}